@@ -223,7 +223,17 @@ func (th *TestHelper) SimulateOVNDBClusterReady(name types.NamespacedName) {
 //	ovnController := th.CreateOVNController(namespace, spec)
 //	DeferCleanup(th.DeleteOVNController, ovnController)
 func (th *TestHelper) CreateOVNController(namespace string, spec ovnv1.OVNControllerSpec) types.NamespacedName {
-	name := "ovncontroller-" + uuid.New().String()
+	return th.CreateNamedOVNController(namespace, "ovncontroller-"+uuid.New().String(), spec)
+}
+
+// CreateNamedOVNController creates a new OVNController instance with the
+// specified namespace and name in the Kubernetes cluster.
+//
+// Example usage:
+//
+//	ovnController := th.CreateNamedOVNController(namespace, ovnv1.OVNControllerLegacyName, spec)
+//	DeferCleanup(th.DeleteOVNController, ovnController)
+func (th *TestHelper) CreateNamedOVNController(namespace string, name string, spec ovnv1.OVNControllerSpec) types.NamespacedName {
 	ovnController := &ovnv1.OVNController{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "ovn.openstack.org/v1beta1",
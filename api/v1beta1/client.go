@@ -18,8 +18,10 @@ package v1beta1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,6 +30,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// ErrDBClusterNotFound - no OVNDBCluster of the requested type exists
+var ErrDBClusterNotFound = errors.New("failed to find DBCluster")
+
 func getDBClusters(
 	ctx context.Context,
 	h *helper.Helper,
@@ -52,6 +57,9 @@ func getDBClusters(
 	return ovnDBList, nil
 }
 
+// GetOVNController - returns the OVNController of the namespace which
+// provides the settings shared with the EDPM nodes. With multiple
+// OVNControllers this is the OVNControllerLegacyName one, or else the oldest.
 func GetOVNController(
 	ctx context.Context,
 	h *helper.Helper,
@@ -65,11 +73,21 @@ func GetOVNController(
 	if err != nil {
 		return nil, err
 	}
-	if len(ovnControllerList.Items) > 0 {
-		return &ovnControllerList.Items[0], nil
+	if len(ovnControllerList.Items) == 0 {
+		return nil, nil
 	}
 
-	return nil, nil
+	items := ovnControllerList.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		if (items[i].Name == OVNControllerLegacyName) != (items[j].Name == OVNControllerLegacyName) {
+			return items[i].Name == OVNControllerLegacyName
+		}
+		if !items[i].CreationTimestamp.Equal(&items[j].CreationTimestamp) {
+			return items[i].CreationTimestamp.Before(&items[j].CreationTimestamp)
+		}
+		return items[i].Name < items[j].Name
+	})
+	return &items[0], nil
 }
 
 // GetDBClusterByType - return OVNDBCluster for the given dbType
@@ -89,7 +107,7 @@ func GetDBClusterByType(
 			return &ovndb, nil
 		}
 	}
-	return nil, fmt.Errorf("failed to find DBCluster of type %s", dbType)
+	return nil, fmt.Errorf("%w of type %s", ErrDBClusterNotFound, dbType)
 }
 
 func getItems(list client.ObjectList) []client.Object {
@@ -16,7 +16,118 @@ limitations under the License.
 
 package v1beta1
 
-import "github.com/openstack-k8s-operators/lib-common/modules/common/util"
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// PodDisruptionBudgetOverrideSpec - overrides the PodDisruptionBudget created by the operator
+type PodDisruptionBudgetOverrideSpec struct {
+	// +kubebuilder:validation:Optional
+	// Enabled - create the PodDisruptionBudget, defaults to true
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// MinAvailable - pods which must remain available during a voluntary disruption.
+	// Replaces the value computed by the operator. Mutually exclusive with MaxUnavailable.
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// MaxUnavailable - pods which may be unavailable during a voluntary disruption.
+	// Replaces the value computed by the operator. Mutually exclusive with MinAvailable.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// ComponentVersion - observed version of an OVN/OVS component and the number
+// of pods running it
+type ComponentVersion struct {
+	// Component - name of the component, e.g. ovn-northd, ovsdb-server, ovs-vswitchd
+	Component string `json:"component"`
+
+	// Version - version reported by the component, empty until it could be queried
+	Version string `json:"version,omitempty"`
+
+	// ImageDigest - image ID of the container running the component
+	ImageDigest string `json:"imageDigest"`
+
+	// Pods - number of pods running this version
+	Pods int32 `json:"pods"`
+}
+
+// PodLogLevels - effective console log levels of a pod
+type PodLogLevels struct {
+	// Levels - effective console log levels, e.g. "info raft:dbg"
+	Levels string `json:"levels"`
+
+	// UID - UID of the pod the levels were read from
+	UID types.UID `json:"uid"`
+
+	// CheckTime - last time the levels were read from the pod
+	CheckTime metav1.Time `json:"checkTime"`
+}
+
+const (
+	// AntiAffinityPreferred - spread the pods across nodes if possible
+	AntiAffinityPreferred = "preferred"
+	// AntiAffinityRequired - never schedule two pods of a service on the same node
+	AntiAffinityRequired = "required"
+)
+
+var (
+	// OVSDBServerAllowedOptions - ovsdb-server options which can be passed via extraArgs
+	OVSDBServerAllowedOptions = []string{
+		"--disable-file-column-diff",
+		"--ssl-ciphers",
+		"--ssl-ciphersuites",
+		"--ssl-protocols",
+		"--sync-exclude-tables",
+	}
+
+	// OVSDBServerAllowedUnixctlCommands - ovsdb-server unixctl commands which can be run via extraArgs
+	OVSDBServerAllowedUnixctlCommands = []string{
+		"cluster/set-backlog-threshold",
+		"ovsdb-server/memory-trim-on-compaction",
+		"ovsdb-server/set-active-ovsdb-server-probe-interval",
+		"ovsdb-server/set-sync-exclude-tables",
+	}
+
+	// OVNNorthdAllowedOptions - ovn-northd options which can be passed via extraArgs
+	OVNNorthdAllowedOptions = []string{
+		"--ssl-ciphers",
+		"--ssl-ciphersuites",
+		"--ssl-protocols",
+	}
+
+	// LogLevels - console log levels supported by vlog/set
+	LogLevels = []string{"off", "emer", "err", "warn", "info", "dbg"}
+
+	// logModuleRegex - vlog module names
+	logModuleRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+	// extraArgValueRegex - values are rendered into scripts and command lines,
+	// so only a safe set of characters is accepted
+	extraArgValueRegex = regexp.MustCompile(`^[A-Za-z0-9_.,:!+=@/-]*$`)
+
+	// globalOptionKeyRegex - keys of the NB_Global and SB_Global options
+	globalOptionKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+	// GlobalOptionsReserved - NB_Global and SB_Global options maintained by ovn-northd
+	GlobalOptionsReserved = []string{"northd_internal_version", "max_tunid"}
+)
 
 // SetupDefaults - initializes any CRD field defaults based on environment variables (the defaulting mechanism itself is implemented via webhooks)
 func SetupDefaults() {
@@ -42,4 +153,187 @@ func SetupDefaults() {
 	}
 
 	SetupOVNControllerDefaults(ovnControllerDefaults)
+
+	// Acquire environmental overrides of the sizing profiles
+	SetupProfileDefaults(profileDefaultsFromEnv())
+}
+
+// validateExtraArgs - validate that args are in the form --option or
+// --option=value and that option is part of allowed
+func validateExtraArgs(path *field.Path, args []string, allowed []string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, arg := range args {
+		option, value, _ := strings.Cut(arg, "=")
+		if !strings.HasPrefix(option, "--") {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), arg, "must be in the form --option or --option=value"))
+			continue
+		}
+		if !util.StringInSlice(option, allowed) {
+			allErrs = append(allErrs, field.NotSupported(path.Index(i), option, allowed))
+			continue
+		}
+		if !extraArgValueRegex.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), arg, "value contains unsupported characters"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateUnixctlCommands - validate that the commands are part of allowed and
+// their arguments only contain supported characters
+func validateUnixctlCommands(path *field.Path, commands []UnixctlCommand, allowed []string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, cmd := range commands {
+		if !util.StringInSlice(cmd.Command, allowed) {
+			allErrs = append(allErrs, field.NotSupported(path.Index(i).Child("command"), cmd.Command, allowed))
+		}
+		for j, arg := range cmd.Args {
+			if arg == "" || !extraArgValueRegex.MatchString(arg) {
+				allErrs = append(allErrs, field.Invalid(path.Index(i).Child("args").Index(j), arg, "argument contains unsupported characters"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateLogLevels - validate the global log level and the per module log levels
+func validateLogLevels(path *field.Path, level string, modules map[string]string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if level != "" && !util.StringInSlice(level, LogLevels) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("logLevel"), level, LogLevels))
+	}
+	for module, moduleLevel := range modules {
+		if !logModuleRegex.MatchString(module) {
+			allErrs = append(allErrs, field.Invalid(path.Child("logModules").Key(module), module, "must be a valid log module name"))
+		}
+		if !util.StringInSlice(moduleLevel, LogLevels) {
+			allErrs = append(allErrs, field.NotSupported(path.Child("logModules").Key(module), moduleLevel, LogLevels))
+		}
+	}
+
+	return allErrs
+}
+
+// validateGlobalOptions - validate the NB_Global or SB_Global options
+func validateGlobalOptions(path *field.Path, options map[string]string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for key, value := range options {
+		if !globalOptionKeyRegex.MatchString(key) {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), key, "must be a valid option name"))
+		}
+		if util.StringInSlice(key, GlobalOptionsReserved) {
+			allErrs = append(allErrs, field.Forbidden(path.Key(key), "maintained by ovn-northd"))
+		}
+		if value == "" || !extraArgValueRegex.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), value, "value is empty or contains unsupported characters"))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePodDisruptionBudget - validate the PodDisruptionBudget override
+func validatePodDisruptionBudget(path *field.Path, pdb *PodDisruptionBudgetOverrideSpec) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if pdb != nil && pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxUnavailable"), pdb.MaxUnavailable.String(),
+			"minAvailable and maxUnavailable are mutually exclusive"))
+	}
+
+	return allErrs
+}
+
+// Fields of the generated workloads which may be overridden, lib-common
+// copies only those to the existing workloads on update
+var (
+	daemonSetOverrideFields   = []string{"template"}
+	statefulSetOverrideFields = []string{
+		"template", "updateStrategy", "revisionHistoryLimit", "minReadySeconds",
+		"persistentVolumeClaimRetentionPolicy",
+	}
+	deploymentOverrideFields = []string{"template", "strategy"}
+)
+
+// workloadSelectorLabels - pod labels of the generated workloads which are
+// part of their selectors
+var workloadSelectorLabels = []string{common.AppSelector, NodeGroupLabel}
+
+// validateWorkloadOverride - validate that override is a strategic merge
+// patch for dataStruct which only touches the labels and annotations of the
+// workload and the specFields of its spec, the other fields are managed by
+// the operator or not applied to existing workloads
+func validateWorkloadOverride(
+	path *field.Path,
+	override *runtime.RawExtension,
+	dataStruct interface{},
+	specFields []string,
+) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if override == nil || len(override.Raw) == 0 {
+		return allErrs
+	}
+
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(override.Raw, &patch); err != nil {
+		return append(allErrs, field.Invalid(path, string(override.Raw), err.Error()))
+	}
+	allowed := map[string][]string{
+		"metadata": {"labels", "annotations"},
+		"spec":     specFields,
+	}
+	for _, key := range sortedKeys(patch) {
+		fields, ok := allowed[key]
+		if !ok {
+			allErrs = append(allErrs, field.Forbidden(path.Child(key), "managed by the operator"))
+			continue
+		}
+		section, ok := patch[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, name := range sortedKeys(section) {
+			if !slices.Contains(fields, name) {
+				allErrs = append(allErrs, field.Forbidden(path.Child(key, name), "managed by the operator"))
+			}
+		}
+	}
+	labels, _, _ := unstructured.NestedMap(patch, "spec", "template", "metadata", "labels")
+	for _, label := range workloadSelectorLabels {
+		if _, found := labels[label]; found {
+			allErrs = append(allErrs, field.Forbidden(
+				path.Child("spec", "template", "metadata", "labels").Key(label),
+				"part of the selector of the workload"))
+		}
+	}
+
+	patched, err := strategicpatch.StrategicMergePatch([]byte("{}"), override.Raw, dataStruct)
+	if err != nil {
+		return append(allErrs, field.Invalid(path, string(override.Raw), err.Error()))
+	}
+	// reject unknown fields, they would be silently dropped
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dataStruct); err != nil {
+		allErrs = append(allErrs, field.Invalid(path, string(override.Raw), err.Error()))
+	}
+
+	return allErrs
+}
+
+// sortedKeys - returns the keys of the map in a stable order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
@@ -0,0 +1,104 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+)
+
+// OVN Condition Types used by API objects.
+const (
+	// DatabaseIntegrityCondition Status=True condition which indicates if the database files of all
+	// cluster members are valid and the members agree on the database contents
+	DatabaseIntegrityCondition condition.Type = "DatabaseIntegrity"
+
+	// VersionCompatibleCondition Status=True condition which indicates if the running OVN
+	// components are not newer than the components they depend on
+	VersionCompatibleCondition condition.Type = "VersionCompatible"
+
+	// NorthdActiveCondition Status=True condition which indicates if exactly one ovn-northd
+	// instance holds the OVN_Southbound lock
+	NorthdActiveCondition condition.Type = "NorthdActive"
+
+	// NBDBReadyCondition Status=True condition which indicates if the endpoint of the
+	// NB OVNDBCluster is available to the component
+	NBDBReadyCondition condition.Type = "NBDBReady"
+
+	// SBDBReadyCondition Status=True condition which indicates if the endpoint of the
+	// SB OVNDBCluster is available to the component
+	SBDBReadyCondition condition.Type = "SBDBReady"
+)
+
+// Common Messages used by API objects.
+const (
+	//
+	// DatabaseIntegrity condition messages
+	//
+	// DatabaseIntegrityReadyMessage
+	DatabaseIntegrityReadyMessage = "Database integrity check passed"
+
+	// DatabaseIntegrityErrorMessage
+	DatabaseIntegrityErrorMessage = "Database integrity check failed: %s"
+
+	//
+	// VersionCompatible condition messages
+	//
+	// VersionCompatibleInitMessage
+	VersionCompatibleInitMessage = "Version compatibility not checked"
+
+	// VersionCompatibleReadyMessage
+	VersionCompatibleReadyMessage = "Running OVN versions are compatible"
+
+	// VersionCompatibleErrorMessage
+	VersionCompatibleErrorMessage = "Unsupported OVN version skew: %s"
+
+	//
+	// NorthdActive condition messages
+	//
+	// NorthdActiveReadyMessage
+	NorthdActiveReadyMessage = "ovn-northd instance %s is active"
+
+	// NorthdNoActiveMessage
+	NorthdNoActiveMessage = "No ovn-northd instance holds the OVN_Southbound lock"
+
+	// NorthdMultipleActiveMessage
+	NorthdMultipleActiveMessage = "Multiple ovn-northd instances claim to be active: %s"
+
+	//
+	// NBDBReady and SBDBReady condition messages
+	//
+	// DBReadyInitMessage
+	DBReadyInitMessage = "%s OVNDBCluster not checked"
+
+	// DBReadyMessage
+	DBReadyMessage = "%s OVNDBCluster %s is available"
+
+	// DBReadyMissingMessage
+	DBReadyMissingMessage = "Waiting for a %s OVNDBCluster to be created"
+
+	// DBReadyWaitingMessage
+	DBReadyWaitingMessage = "Waiting for the %s OVNDBCluster %s: %s"
+
+	// DBReadyErrorMessage
+	DBReadyErrorMessage = "Error getting the %s OVNDBCluster: %s"
+
+	//
+	// ServiceConfigReady condition messages of the OVNController
+	//
+	// NodeConfigApplyingMessage
+	NodeConfigApplyingMessage = "Node configuration applied on %d of %d nodes"
+)
@@ -22,12 +22,17 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
-	// OVNConfigHash - OVNConfigHash key
+	// OVNConfigHash - OVNConfigHash key, the prefix of the hashes of the per
+	// node config jobs which were replaced by the config agent of the ovs pods
 	OVNConfigHash = "OvnConfigHash"
 
+	// NodeGroupLabel - label of the ovs pods and DaemonSets of a node group
+	NodeGroupLabel = "ovn.openstack.org/node-group"
+
 	// Container image fall-back defaults
 
 	// OVNControllerOVSContainerImage is the fall-back container image for OVNController ovs-*
@@ -42,6 +47,24 @@ const (
 
 	// ServiceNameOVS - ovn-controller-ovs service name
 	ServiceNameOVS = "ovn-controller-ovs"
+
+	// OVNControllerLegacyName - name of the OVNController created by the
+	// OpenStackControlPlane, it keeps the DaemonSet names, labels and host paths
+	// used before multiple OVNControllers per namespace were supported
+	OVNControllerLegacyName = "ovncontroller"
+
+	// RemediateDriftAnnotation - annotation of the OVNController, setting or
+	// changing its value re-applies the node configuration on all nodes
+	RemediateDriftAnnotation = "ovn.openstack.org/remediate-drift"
+)
+
+const (
+	// DriftRemediationAutomatic - external-ids which drifted from the spec are
+	// re-applied as soon as the drift is detected
+	DriftRemediationAutomatic = "Automatic"
+	// DriftRemediationManual - drifted external-ids are only reported until the
+	// RemediateDriftAnnotation of the OVNController is set or changed
+	DriftRemediationManual = "Manual"
 )
 
 // OVNControllerSpec defines the desired state of OVNController
@@ -59,10 +82,30 @@ type OVNControllerSpec struct {
 
 // OVNControllerSpecCore -
 type OVNControllerSpecCore struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=small;medium;large;custom
+	// Profile - sizing profile which sets external-ids.ovn-remote-probe-interval and
+	// resources unless they are set explicitly
+	Profile string `json:"profile,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default={}
 	ExternalIDS OVSExternalIDs `json:"external-ids"`
 
+	// +kubebuilder:validation:Optional
+	// ExtraExternalIDs - additional external-ids of the Open_vSwitch table, e.g.
+	// ovn-openflow-probe-interval, ovn-monitor-all or ovn-chassis-mac-mappings.
+	// The keys set from the spec can not be used, values must not contain
+	// whitespace. Keys removed from the map are removed from OVS.
+	ExtraExternalIDs map[string]string `json:"extraExternalIDs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OVSOtherConfig - other_config of the Open_vSwitch table, e.g.
+	// n-handler-threads, n-revalidator-threads or max-idle. Values must not
+	// contain whitespace. Keys removed from the map are removed from OVS.
+	// They are not checked for drift.
+	OVSOtherConfig map[string]string `json:"ovsOtherConfig,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +optional
 	NicMappings map[string]string `json:"nicMappings,omitempty"`
@@ -73,9 +116,24 @@ type OVNControllerSpecCore struct {
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 
 	// +kubebuilder:validation:Optional
-	// NodeSelector to target subset of worker nodes running this service
+	// ContainerResources - Compute Resources of the individual containers.
+	// Containers without an entry use Resources.
+	ContainerResources OVNControllerContainerResources `json:"containerResources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NodeSelector to target subset of worker nodes running this service. The
+	// node selectors of the OVNControllers of a namespace must not overlap.
 	NodeSelector *map[string]string `json:"nodeSelector,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// Tolerations - allow the pods to be scheduled on tainted nodes, e.g.
+	// control-plane or networker nodes
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PriorityClassName - priority class of the pods
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// NetworkAttachment is a NetworkAttachment resource name to expose the service to the given network.
 	// If specified the IP address of this network is used as the OVNEncapIP.
@@ -85,6 +143,172 @@ type OVNControllerSpecCore struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// TLS - Parameters related to TLS
 	TLS tls.SimpleService `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Override, provides the ability to override the generated manifest of several child resources.
+	Override OVNControllerOverrideSpec `json:"override,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// NodeGroups - settings for subsets of the nodes, e.g. compute, networker and
+	// gateway nodes. A node gets the settings of the first group it matches, nodes
+	// without a group get the settings of the spec.
+	NodeGroups []OVNControllerNodeGroup `json:"nodeGroups,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Automatic
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	// ExternalIDsDriftRemediation - how external-ids of a node which no longer
+	// match the spec, e.g. after a manual ovs-vsctl set, are corrected. Automatic
+	// re-applies them once the drift is detected, Manual only reports the drift
+	// in the status until the ovn.openstack.org/remediate-drift annotation of
+	// the OVNController is set or changed.
+	ExternalIDsDriftRemediation string `json:"externalIDsDriftRemediation,omitempty"`
+}
+
+const (
+	// NodeGroupDefault - name of the nodes which are not part of any node group
+	NodeGroupDefault = "default"
+)
+
+// OVNControllerNodeGroup - overrides for the nodes matching a label selector
+type OVNControllerNodeGroup struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// Name - name of the group, used in the names of its DaemonSet and NetworkAttachmentDefinitions
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinProperties=1
+	// NodeSelector - labels of the nodes in the group, in addition to spec.nodeSelector
+	NodeSelector map[string]string `json:"nodeSelector"`
+
+	// +kubebuilder:validation:Optional
+	// NicMappings - replaces spec.nicMappings on the nodes of the group
+	NicMappings *map[string]string `json:"nicMappings,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExternalIDS - replaces the given spec.external-ids on the nodes of the group
+	ExternalIDS *OVSExternalIDsOverride `json:"external-ids,omitempty"`
+}
+
+// OVSExternalIDsOverride - external-ids which can be set per node group, unset
+// fields keep the value of spec.external-ids
+type OVSExternalIDsOverride struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum={"geneve","vxlan"}
+	OvnEncapType string `json:"ovn-encap-type,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	OvnAvailabilityZones []string `json:"availability-zones,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	EnableChassisAsGateway *bool `json:"enable-chassis-as-gateway,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	OvnRemoteProbeInterval *int32 `json:"ovn-remote-probe-interval,omitempty"`
+}
+
+// OVNControllerNodeGroupStatus - number of nodes and ready pods of a node group
+type OVNControllerNodeGroupStatus struct {
+	// Name - name of the group, default for the nodes without a group
+	Name string `json:"name"`
+
+	// DesiredNumberScheduled - number of nodes in the group
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+
+	// NumberReady - ready ovn-controller pods on the nodes of the group
+	NumberReady int32 `json:"numberReady"`
+
+	// OVSNumberReady - ready ovs pods on the nodes of the group
+	OVSNumberReady int32 `json:"ovsNumberReady"`
+
+	// ConfigNumberApplied - ovs pods of the group which applied the current
+	// node configuration
+	ConfigNumberApplied int32 `json:"configNumberApplied"`
+}
+
+// OVNControllerNodeStatus - state of ovn-controller and ovs on a node, as
+// reported by the pods and the config agent of the node
+type OVNControllerNodeStatus struct {
+	// Node - name of the node
+	Node string `json:"node"`
+
+	// NodeGroup - node group of the node
+	NodeGroup string `json:"nodeGroup,omitempty"`
+
+	// SystemID - system-id of the chassis
+	SystemID string `json:"systemID,omitempty"`
+
+	// EncapIP - tunnel endpoint IP of the chassis
+	EncapIP string `json:"encapIP,omitempty"`
+
+	// BridgeMappings - ovn-bridge-mappings in place on the node
+	BridgeMappings []string `json:"bridgeMappings,omitempty"`
+
+	// ConfigHash - hash of the node configuration the config agent applied
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// ConfigUpToDate - the applied node configuration is the current one of
+	// the node group
+	ConfigUpToDate bool `json:"configUpToDate"`
+
+	// ConfigAppliedTime - time the config agent applied the node configuration
+	ConfigAppliedTime *metav1.Time `json:"configAppliedTime,omitempty"`
+
+	// ConfigError - last error of the config agent applying the node
+	// configuration, empty once it was applied
+	ConfigError string `json:"configError,omitempty"`
+
+	// OVNControllerConnected - ovn-controller is connected to the SB database
+	OVNControllerConnected bool `json:"ovnControllerConnected"`
+
+	// OVSReady - ovs-vswitchd is ready
+	OVSReady bool `json:"ovsReady"`
+
+	// ExternalIDsDrift - keys of the external-ids which differ from the spec,
+	// empty once they were remediated
+	ExternalIDsDrift []string `json:"externalIDsDrift,omitempty"`
+
+	// LastRemediationTime - last time the drifted external-ids of the node
+	// were re-applied automatically
+	LastRemediationTime *metav1.Time `json:"lastRemediationTime,omitempty"`
+}
+
+// OVNControllerContainerResources - Compute Resources of the individual containers
+type OVNControllerContainerResources struct {
+	// +kubebuilder:validation:Optional
+	// OVSDBServer - resources of the ovsdb-server container
+	OVSDBServer *corev1.ResourceRequirements `json:"ovsdbServer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OVSVswitchd - resources of the ovs-vswitchd container
+	OVSVswitchd *corev1.ResourceRequirements `json:"ovsVswitchd,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OVNController - resources of the ovn-controller container
+	OVNController *corev1.ResourceRequirements `json:"ovnController,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ConfigAgent - resources of the ovn-config container of the ovs pods, which
+	// applies the node configuration
+	ConfigAgent *corev1.ResourceRequirements `json:"configAgent,omitempty"`
+}
+
+// OVNControllerOverrideSpec to override the generated manifest of several child resources.
+type OVNControllerOverrideSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Override for the generated ovn-controller DaemonSet, applied as strategic merge patch.
+	DaemonSet *runtime.RawExtension `json:"daemonSet,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Override for the generated ovn-controller-ovs DaemonSet, applied as strategic merge patch.
+	OVSDaemonSet *runtime.RawExtension `json:"ovsDaemonSet,omitempty"`
 }
 
 // OVNControllerStatus defines the observed state of OVNController
@@ -95,6 +319,9 @@ type OVNControllerStatus struct {
 	// ovsNumberReady of ovs instances
 	OVSNumberReady int32 `json:"ovsNumberReady,omitempty"`
 
+	// ConfigNumberApplied - ovs pods which applied the current node configuration
+	ConfigNumberApplied int32 `json:"configNumberApplied,omitempty"`
+
 	// DesiredNumberScheduled - total number of the nodes which should be running Daemon
 	DesiredNumberScheduled int32 `json:"desiredNumberScheduled,omitempty"`
 
@@ -109,6 +336,18 @@ type OVNControllerStatus struct {
 
 	//ObservedGeneration - the most recent generation observed for this service. If the observed generation is less than the spec generation, then the controller has not processed the latest changes.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Versions - observed ovn-controller, ovsdb-server and ovs-vswitchd versions
+	Versions []ComponentVersion `json:"versions,omitempty"`
+
+	// SBEndpoint - OVN_Southbound endpoint the nodes are configured with
+	SBEndpoint string `json:"sbEndpoint,omitempty"`
+
+	// NodeGroups - status of the node groups, set if spec.nodeGroups is used
+	NodeGroups []OVNControllerNodeGroupStatus `json:"nodeGroups,omitempty"`
+
+	// Nodes - state of each node running an ovn-controller or ovs pod
+	Nodes []OVNControllerNodeStatus `json:"nodes,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -168,6 +407,12 @@ type OVSExternalIDs struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=true
 	EnableChassisAsGateway *bool `json:"enable-chassis-as-gateway"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// OvnRemoteProbeInterval - inactivity probe of the connection to the SB database
+	// (in milliseconds, 0 disables it, defaults to the profile value)
+	OvnRemoteProbeInterval *int32 `json:"ovn-remote-probe-interval,omitempty"`
 }
 
 // RbacConditionsSet - set the conditions for the rbac object
@@ -184,3 +429,12 @@ func (instance OVNController) RbacNamespace() string {
 func (instance OVNController) RbacResourceName() string {
 	return "ovncontroller-" + instance.Name
 }
+
+// ScopedName - returns the name of a resource of the OVNController prefixed
+// with the name of the CR, unchanged for the OVNControllerLegacyName CR
+func (instance OVNController) ScopedName(name string) string {
+	if instance.Name == OVNControllerLegacyName {
+		return name
+	}
+	return instance.Name + "-" + name
+}
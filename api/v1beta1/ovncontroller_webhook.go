@@ -17,8 +17,19 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -32,6 +43,55 @@ type OVNControllerDefaults struct {
 
 var ovnDefaults OVNControllerDefaults
 
+// webhookClient - client to check the OVNController against the other
+// OVNControllers of its namespace
+var webhookClient client.Client
+
+const (
+	// interfaceNameMaxLength - maximum length of a Linux interface name (IFNAMSIZ - 1)
+	interfaceNameMaxLength = 15
+	// physnetBridgePrefix - prefix of the OVS bridge created for each physnet
+	physnetBridgePrefix = "br-"
+	// nodeGroupAffinityTermsMax - the nodes of a group exclude the nodes of the
+	// groups before it, every label of those groups multiplies the number of
+	// node affinity terms
+	nodeGroupAffinityTermsMax = 64
+	// scopedNameMaxLength - the names of the OVNControllers other than
+	// OVNControllerLegacyName prefix the service label values, which are at
+	// most 63 characters
+	scopedNameMaxLength = validation.LabelValueMaxLength - len("-"+ServiceNameOVS)
+)
+
+var (
+	// OVNEncapTypes - supported tunnel encapsulation types
+	OVNEncapTypes = []string{"geneve", "vxlan"}
+
+	// interfaceNameRegex - host interface names accepted in nicMappings
+	interfaceNameRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+	// availabilityZoneRegex - availability zones are rendered into the
+	// ovn-cms-options external-id, which uses ',' and ':' as separators
+	availabilityZoneRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+	// ovsKeyRegex - keys of extraExternalIDs and ovsOtherConfig
+	ovsKeyRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+	// ovsValueRegex - values of extraExternalIDs and ovsOtherConfig, they are
+	// rendered into the whitespace separated node configuration
+	ovsValueRegex = regexp.MustCompile(`^[A-Za-z0-9_.:,/@=+-]*$`)
+
+	// managedExternalIDs - external-ids set from the spec or by the ovs pods,
+	// they can not be overridden with extraExternalIDs
+	managedExternalIDs = []string{
+		"system-id", "hostname", "ovn-bridge", "ovn-remote", "ovn-encap-type",
+		"ovn-encap-ip", "ovn-cms-options", "ovn-bridge-mappings", "ovn-remote-probe-interval",
+	}
+
+	// managedOVSOtherConfig - other_config keys set by the ovs pods, they can
+	// not be overridden with ovsOtherConfig
+	managedOVSOtherConfig = []string{"flow-restore-wait"}
+)
+
 // log is for logging in this package.
 var ovncontrollerlog = logf.Log.WithName("ovncontroller-resource")
 
@@ -42,6 +102,10 @@ func SetupOVNControllerDefaults(defaults OVNControllerDefaults) {
 }
 
 func (r *OVNController) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if webhookClient == nil {
+		webhookClient = mgr.GetClient()
+	}
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -71,7 +135,12 @@ func (spec *OVNControllerSpec) Default() {
 
 // Default - set defaults for this OVNController core spec (this version is called by OpenStackControlplane webhooks)
 func (spec *OVNControllerSpecCore) Default() {
-	// nothing here yet
+	// the profile only fills the fields which are not set explicitly, without
+	// a profile ovn-controller keeps its builtin defaults
+	if profile, ok := GetProfileDefaults(spec.Profile); ok {
+		defaultInt32Ptr(&spec.ExternalIDS.OvnRemoteProbeInterval, profile.RemoteProbeInterval)
+		defaultResources(&spec.Resources, profile.ControllerResources)
+	}
 }
 
 //+kubebuilder:webhook:path=/validate-ovn-openstack-org-v1beta1-ovncontroller,mutating=false,failurePolicy=fail,sideEffects=None,groups=ovn.openstack.org,resources=ovncontrollers,verbs=create;update,versions=v1beta1,name=vovncontroller.kb.io,admissionReviewVersions=v1
@@ -82,6 +151,13 @@ var _ webhook.Validator = &OVNController{}
 func (r *OVNController) ValidateCreate() (admission.Warnings, error) {
 	ovncontrollerlog.Info("validate create", "name", r.Name)
 
+	allErrs := r.Spec.ValidateCreate(field.NewPath("spec"))
+	allErrs = append(allErrs, r.validateScope()...)
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "ovn.openstack.org", Kind: "OVNController"},
+			r.Name, allErrs)
+	}
 	return nil, nil
 }
 
@@ -89,7 +165,268 @@ func (r *OVNController) ValidateCreate() (admission.Warnings, error) {
 func (r *OVNController) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	ovncontrollerlog.Info("validate update", "name", r.Name)
 
-	return nil, nil
+	oldInstance, ok := old.(*OVNController)
+	if !ok || oldInstance == nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("unable to convert existing object"))
+	}
+
+	warnings := r.Spec.UpdateWarnings(oldInstance.Spec.OVNControllerSpecCore, field.NewPath("spec"))
+	allErrs := r.Spec.ValidateUpdate(oldInstance.Spec.OVNControllerSpecCore, field.NewPath("spec"))
+	allErrs = append(allErrs, r.validateScope()...)
+	if len(allErrs) != 0 {
+		return warnings, apierrors.NewInvalid(
+			schema.GroupKind{Group: "ovn.openstack.org", Kind: "OVNController"},
+			r.Name, allErrs)
+	}
+	return warnings, nil
+}
+
+// ValidateCreate - validates the OVNController core spec on creation (this version is called by OpenStackControlplane webhooks)
+func (spec *OVNControllerSpecCore) ValidateCreate(basePath *field.Path) field.ErrorList {
+	return spec.validate(basePath)
+}
+
+// ValidateUpdate - validates the OVNController core spec on update (this version is called by OpenStackControlplane webhooks)
+func (spec *OVNControllerSpecCore) ValidateUpdate(old OVNControllerSpecCore, basePath *field.Path) field.ErrorList {
+	return spec.validate(basePath)
+}
+
+func (spec *OVNControllerSpecCore) validate(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validateNicMappings(basePath.Child("nicMappings"), spec.NicMappings)...)
+	allErrs = append(allErrs, spec.ExternalIDS.validate(basePath.Child("external-ids"))...)
+	allErrs = append(allErrs, validateOVSMap(basePath.Child("extraExternalIDs"), spec.ExtraExternalIDs, managedExternalIDs)...)
+	allErrs = append(allErrs, validateOVSMap(basePath.Child("ovsOtherConfig"), spec.OVSOtherConfig, managedOVSOtherConfig)...)
+	if _, ok := spec.NicMappings[spec.NetworkAttachment]; ok {
+		allErrs = append(allErrs, field.Invalid(basePath.Child("networkAttachment"), spec.NetworkAttachment,
+			"must not be the name of a physnet in nicMappings"))
+	}
+	allErrs = append(allErrs, spec.validateNodeGroups(basePath.Child("nodeGroups"))...)
+
+	overridePath := basePath.Child("override")
+	allErrs = append(allErrs, validateWorkloadOverride(
+		overridePath.Child("daemonSet"), spec.Override.DaemonSet, &appsv1.DaemonSet{}, daemonSetOverrideFields)...)
+	allErrs = append(allErrs, validateWorkloadOverride(
+		overridePath.Child("ovsDaemonSet"), spec.Override.OVSDaemonSet, &appsv1.DaemonSet{}, daemonSetOverrideFields)...)
+
+	return allErrs
+}
+
+// validateScope - validate that the name fits into the scoped resource names
+// and that no other OVNController of the namespace selects the same nodes, the
+// ovs pods of both would manage the same OVS instance
+func (r *OVNController) validateScope() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Name != OVNControllerLegacyName && len(r.Name) > scopedNameMaxLength {
+		allErrs = append(allErrs, field.TooLong(field.NewPath("metadata", "name"), r.Name, scopedNameMaxLength))
+	}
+
+	if webhookClient == nil {
+		return allErrs
+	}
+	path := field.NewPath("spec", "nodeSelector")
+	ovnControllers := &OVNControllerList{}
+	if err := webhookClient.List(context.TODO(), ovnControllers, client.InNamespace(r.Namespace)); err != nil {
+		return append(allErrs, field.InternalError(path, err))
+	}
+	for _, other := range ovnControllers.Items {
+		if other.Name == r.Name {
+			continue
+		}
+		if nodeSelectorsOverlap(r.Spec.NodeSelector, other.Spec.NodeSelector) {
+			allErrs = append(allErrs, field.Invalid(path, r.Spec.NodeSelector,
+				fmt.Sprintf("overlaps with the nodeSelector of OVNController %s", other.Name)))
+		}
+	}
+
+	return allErrs
+}
+
+// nodeSelectorsOverlap - returns true unless the selectors require different
+// values of the same label, a missing selector selects all nodes
+func nodeSelectorsOverlap(a *map[string]string, b *map[string]string) bool {
+	if a == nil || b == nil {
+		return true
+	}
+	for key, value := range *a {
+		if other, ok := (*b)[key]; ok && other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// validateOVSMap - validate the keys and values of a map column of the
+// Open_vSwitch table, the reserved keys are set by the operator
+func validateOVSMap(path *field.Path, m map[string]string, reserved []string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, key := range sortedKeys(m) {
+		if util.StringInSlice(key, reserved) {
+			allErrs = append(allErrs, field.Forbidden(path.Key(key), "is set by the operator"))
+			continue
+		}
+		if !ovsKeyRegex.MatchString(key) {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), key,
+				"must consist of alphanumeric characters, '_', '.' or '-' and start with an alphanumeric character"))
+		}
+		if !ovsValueRegex.MatchString(m[key]) {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), m[key],
+				"must consist of alphanumeric characters or any of '_.:,/@=+-'"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateNicMappings - validate that the physnets are valid NetworkAttachmentDefinition
+// names whose bridge name fits into an interface name, and that the NICs are valid
+// interface names
+func validateNicMappings(path *field.Path, nicMappings map[string]string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, physnet := range sortedKeys(nicMappings) {
+		for _, msg := range validation.IsDNS1123Label(physnet) {
+			allErrs = append(allErrs, field.Invalid(path.Key(physnet), physnet, msg))
+		}
+		if len(physnetBridgePrefix+physnet) > interfaceNameMaxLength {
+			allErrs = append(allErrs, field.TooLong(path.Key(physnet), physnet,
+				interfaceNameMaxLength-len(physnetBridgePrefix)))
+		}
+		nic := nicMappings[physnet]
+		if !interfaceNameRegex.MatchString(nic) || len(nic) > interfaceNameMaxLength {
+			allErrs = append(allErrs, field.Invalid(path.Key(physnet), nic,
+				fmt.Sprintf("must be an interface name of at most %d characters", interfaceNameMaxLength)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateNodeGroups - validate the names, selectors and overrides of the node groups
+func (spec *OVNControllerSpecCore) validateNodeGroups(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := map[string]bool{}
+	affinityTerms := 1
+	for i, group := range spec.NodeGroups {
+		groupPath := path.Index(i)
+		if group.Name == NodeGroupDefault {
+			allErrs = append(allErrs, field.Invalid(groupPath.Child("name"), group.Name,
+				fmt.Sprintf("%s is reserved for the nodes without a group", NodeGroupDefault)))
+		}
+		for _, msg := range validation.IsDNS1123Label(group.Name) {
+			allErrs = append(allErrs, field.Invalid(groupPath.Child("name"), group.Name, msg))
+		}
+		if names[group.Name] {
+			allErrs = append(allErrs, field.Duplicate(groupPath.Child("name"), group.Name))
+		}
+		names[group.Name] = true
+
+		if len(group.NodeSelector) == 0 {
+			allErrs = append(allErrs, field.Required(groupPath.Child("nodeSelector"),
+				"a node group must select a subset of the nodes"))
+		}
+		if affinityTerms > nodeGroupAffinityTermsMax {
+			allErrs = append(allErrs, field.Invalid(groupPath.Child("nodeSelector"), group.NodeSelector,
+				fmt.Sprintf("the node groups before it use too many labels, the exclusion needs more than %d node affinity terms",
+					nodeGroupAffinityTermsMax)))
+		}
+		affinityTerms *= max(len(group.NodeSelector), 1)
+
+		if group.NicMappings != nil {
+			allErrs = append(allErrs, validateNicMappings(groupPath.Child("nicMappings"), *group.NicMappings)...)
+			if _, ok := (*group.NicMappings)[spec.NetworkAttachment]; ok {
+				allErrs = append(allErrs, field.Invalid(groupPath.Child("nicMappings"), spec.NetworkAttachment,
+					"the networkAttachment must not be the name of a physnet"))
+			}
+		}
+		if group.ExternalIDS != nil {
+			allErrs = append(allErrs, validateEncapAndZones(groupPath.Child("external-ids"),
+				group.ExternalIDS.OvnEncapType, group.ExternalIDS.OvnAvailabilityZones)...)
+		}
+	}
+
+	return allErrs
+}
+
+// validate - validate the encapsulation type and the availability zones
+func (ids *OVSExternalIDs) validate(path *field.Path) field.ErrorList {
+	return validateEncapAndZones(path, ids.OvnEncapType, ids.OvnAvailabilityZones)
+}
+
+// validateEncapAndZones - validate the encapsulation type and the availability zones
+func validateEncapAndZones(path *field.Path, encapType string, zones []string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if encapType != "" && !util.StringInSlice(encapType, OVNEncapTypes) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("ovn-encap-type"), encapType, OVNEncapTypes))
+	}
+	seen := map[string]bool{}
+	for i, az := range zones {
+		if !availabilityZoneRegex.MatchString(az) {
+			allErrs = append(allErrs, field.Invalid(path.Child("availability-zones").Index(i), az,
+				"must consist of alphanumeric characters, '_', '.' or '-' and start with an alphanumeric character"))
+		}
+		if seen[az] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("availability-zones").Index(i), az))
+		}
+		seen[az] = true
+	}
+
+	return allErrs
+}
+
+// UpdateWarnings - returns warnings about disruptive changes of the OVNController core spec (this version is called by OpenStackControlplane webhooks)
+func (spec *OVNControllerSpecCore) UpdateWarnings(old OVNControllerSpecCore, basePath *field.Path) admission.Warnings {
+	var warnings admission.Warnings
+
+	nicMappingsPath := basePath.Child("nicMappings")
+	for _, physnet := range sortedKeys(old.NicMappings) {
+		nic, ok := spec.NicMappings[physnet]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s: removing the physnet deletes bridge %s%s and its patch ports on all nodes, "+
+					"traffic of the networks using it is interrupted",
+				nicMappingsPath.Key(physnet), physnetBridgePrefix, physnet))
+		} else if nic != old.NicMappings[physnet] {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s: moving bridge %s%s from %s to %s interrupts the traffic of the networks using it",
+				nicMappingsPath.Key(physnet), physnetBridgePrefix, physnet, old.NicMappings[physnet], nic))
+		}
+	}
+
+	idsPath := basePath.Child("external-ids")
+	if spec.ExternalIDS.OvnEncapType != old.ExternalIDS.OvnEncapType {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: changing the encapsulation type recreates the tunnels between all chassis",
+			idsPath.Child("ovn-encap-type")))
+	}
+	if spec.ExternalIDS.OvnBridge != old.ExternalIDS.OvnBridge {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: changing the integration bridge requires the flows to be reinstalled on all nodes",
+			idsPath.Child("ovn-bridge")))
+	}
+	if spec.NetworkAttachment != old.NetworkAttachment {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: changing the network attachment changes the tunnel endpoint IP of all chassis",
+			basePath.Child("networkAttachment")))
+	}
+	groups := map[string]bool{}
+	for _, group := range spec.NodeGroups {
+		groups[group.Name] = true
+	}
+	for _, group := range old.NodeGroups {
+		if !groups[group.Name] {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s: removing node group %s restarts the ovs pods of its nodes with the settings of the spec",
+				basePath.Child("nodeGroups"), group.Name))
+		}
+	}
+
+	return warnings
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
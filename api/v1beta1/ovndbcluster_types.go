@@ -25,6 +25,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
@@ -39,6 +41,15 @@ const (
 	ServiceHeadlessType = "headless"
 	// ServiceClusterType - Constant to identify Cluster services
 	ServiceClusterType = "cluster"
+	// ServiceActiveType - Constant to identify the service pointing to the active member
+	ServiceActiveType = "active"
+
+	// DBModeStandalone - a single ovsdb-server serving a standalone database
+	DBModeStandalone = "standalone"
+	// DBModeActiveBackup - an active ovsdb-server and a backup one replicating from it
+	DBModeActiveBackup = "active-backup"
+	// DBModeClustered - ovsdb-servers forming a RAFT cluster
+	DBModeClustered = "clustered"
 
 	// Container image fall-back defaults
 
@@ -67,41 +78,96 @@ type OVNDBClusterSpecCore struct {
 	DBType string `json:"dbType"`
 
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:default=1
+	// +kubebuilder:validation:Enum=small;medium;large;custom
+	// Profile - sizing profile which sets replicas, electionTimer, inactivityProbe,
+	// probeIntervalToActive and resources unless they are set explicitly
+	Profile string `json:"profile,omitempty"`
+
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Maximum=32
 	// +kubebuilder:validation:Minimum=0
-	// Replicas of OVN DBCluster to run
+	// Replicas of OVN DBCluster to run (defaults to the profile value or 1)
 	Replicas *int32 `json:"replicas"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=clustered
+	// +kubebuilder:validation:Enum=standalone;active-backup;clustered
+	// Mode - standalone runs a single ovsdb-server, active-backup runs two
+	// ovsdb-servers where the backup replicates from the active one and
+	// clustered runs a RAFT cluster
+	Mode string `json:"mode,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=70
+	// +kubebuilder:validation:Minimum=10
+	// FailoverTimeout - time the active member may stay not ready before the
+	// backup is promoted in active-backup mode (in seconds). It should cover
+	// the startup probe of a restarting member.
+	FailoverTimeout int32 `json:"failoverTimeout,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// NodeSelector to target subset of worker nodes running this service
 	NodeSelector *map[string]string `json:"nodeSelector,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// Tolerations - allow the pods to be scheduled on tainted nodes, e.g.
+	// control-plane or networker nodes
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PriorityClassName - priority class of the pods
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TopologySpreadConstraints - spread the pods across topology domains,
+	// e.g. topology.kubernetes.io/zone. The pods of the service are selected if
+	// no labelSelector is given.
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=preferred
+	// +kubebuilder:validation:Enum=preferred;required
+	// AntiAffinity - preferred spreads the pods across nodes if possible,
+	// required never schedules two pods of the service on the same node
+	AntiAffinity string `json:"antiAffinity,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=info
 	// LogLevel - Set log level info, dbg, emer etc
 	LogLevel string `json:"logLevel,omitempty"`
 
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:default=10000
-	// OVN Northbound and Southbound RAFT db election timer to use on db creation (in milliseconds)
+	// LogModules - Per module log levels, e.g. raft: dbg. Modules not listed use
+	// LogLevel. Changes are applied to the running processes without a restart
+	LogModules map[string]string `json:"logModules,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OVN Northbound and Southbound RAFT db election timer to use on db creation (in milliseconds,
+	// 0 or unset defaults to the profile value or 10000)
 	ElectionTimer int32 `json:"electionTimer"`
 
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:default=60000
-	// Probe interval for the OVSDB session (in milliseconds)
-	InactivityProbe int32 `json:"inactivityProbe"`
+	// +kubebuilder:validation:Minimum=0
+	// Probe interval for the OVSDB session (in milliseconds, 0 disables it, defaults to the
+	// profile value or 60000)
+	InactivityProbe *int32 `json:"inactivityProbe,omitempty"`
 
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:default=60000
-	// Active probe interval from standby to active ovsdb-server remote
-	ProbeIntervalToActive int32 `json:"probeIntervalToActive"`
+	// +kubebuilder:validation:Minimum=0
+	// Active probe interval from standby to active ovsdb-server remote (in milliseconds,
+	// 0 disables it, defaults to the profile value or 60000)
+	ProbeIntervalToActive *int32 `json:"probeIntervalToActive,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	// Resources - Compute Resources required by this service (Limits/Requests).
 	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// ContainerResources - Compute Resources of the individual containers.
+	// Containers without an entry use Resources.
+	ContainerResources OVNDBClusterContainerResources `json:"containerResources,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// StorageClass
 	StorageClass string `json:"storageClass,omitempty"`
@@ -123,12 +189,107 @@ type OVNDBClusterSpecCore struct {
 	// +kubebuilder:validation:Optional
 	// Override, provides the ability to override the generated manifest of several child resources.
 	Override OVNDBClusterOverrideSpec `json:"override,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IntegrityCheck - periodically validate the database files of the cluster members
+	IntegrityCheck OVNDBClusterIntegrityCheckSpec `json:"integrityCheck,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraArgs - additional ovsdb-server settings which are not exposed as dedicated fields
+	ExtraArgs OVNDBClusterExtraArgs `json:"extraArgs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Options - NB_Global or SB_Global options, depending on DBType, e.g.
+	// northd_probe_interval or mac_prefix. They are kept in sync with the
+	// database, options which are removed here are removed from the database.
+	// Options not listed here are left untouched.
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// OVNDBClusterExtraArgs defines additional ovsdb-server settings
+type OVNDBClusterExtraArgs struct {
+	// +kubebuilder:validation:Optional
+	// ServerOptions - additional ovsdb-server command line options in the
+	// form --option or --option=value. Only a subset of the ovsdb-server
+	// options is accepted, see OVSDBServerAllowedOptions.
+	ServerOptions []string `json:"serverOptions,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Unixctl - commands run through ovs-appctl against every ovsdb-server once
+	// it is started. Only a subset of the commands is accepted, see
+	// OVSDBServerAllowedUnixctlCommands.
+	Unixctl []UnixctlCommand `json:"unixctl,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Remote - options of the remote connection the clients connect to
+	Remote OVNDBRemoteOptions `json:"remote,omitempty"`
+}
+
+// UnixctlCommand is a command run through ovs-appctl
+type UnixctlCommand struct {
+	// +kubebuilder:validation:Required
+	// Command - name of the command, e.g. ovsdb-server/memory-trim-on-compaction
+	Command string `json:"command"`
+
+	// +kubebuilder:validation:Optional
+	// Args - arguments of the command
+	Args []string `json:"args,omitempty"`
+}
+
+// OVNDBRemoteOptions defines the options of an ovsdb-server remote connection
+type OVNDBRemoteOptions struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=63
+	// Dscp - DSCP value set on the packets of the connection
+	Dscp *int32 `json:"dscp,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1000
+	// MaxBackoff - maximum number of milliseconds to wait between connection attempts
+	MaxBackoff *int32 `json:"maxBackoff,omitempty"`
+}
+
+// OVNDBClusterIntegrityCheckSpec defines the periodic database integrity check
+type OVNDBClusterIntegrityCheckSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - run ovsdb-tool check-cluster and compare the members of the cluster periodically.
+	// The check runs in an additional integrity-check container of the database pods, its
+	// result is informational and doesn't affect the Ready condition.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=60
+	// Interval between two integrity checks of a member (in seconds)
+	Interval int32 `json:"interval,omitempty"`
+}
+
+// OVNDBClusterContainerResources - Compute Resources of the individual containers
+type OVNDBClusterContainerResources struct {
+	// +kubebuilder:validation:Optional
+	// OVSDBServer - resources of the ovsdb-server container
+	OVSDBServer *corev1.ResourceRequirements `json:"ovsdbServer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IntegrityCheck - resources of the integrity-check container
+	IntegrityCheck *corev1.ResourceRequirements `json:"integrityCheck,omitempty"`
 }
 
 // OVNDBClusterOverrideSpec to override the generated manifest of several child resources.
 type OVNDBClusterOverrideSpec struct {
 	// Override configuration for the Service created to serve traffic to the cluster.
 	Service *service.OverrideSpec `json:"service,omitempty"`
+
+	// Override configuration for the PodDisruptionBudget of the cluster members.
+	// By default it preserves the RAFT quorum for the current Replicas.
+	PodDisruptionBudget *PodDisruptionBudgetOverrideSpec `json:"podDisruptionBudget,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Override for the generated StatefulSet, applied as strategic merge patch.
+	StatefulSet *runtime.RawExtension `json:"statefulSet,omitempty"`
 }
 
 // OVNDBClusterStatus defines the observed state of OVNDBCluster
@@ -153,6 +314,78 @@ type OVNDBClusterStatus struct {
 
 	//ObservedGeneration - the most recent generation observed for this service. If the observed generation is less than the spec generation, then the controller has not processed the latest changes.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ActiveMember - pod serving the database in standalone and active-backup
+	// mode. It is kept while converting to clustered mode and bootstraps the
+	// cluster from its data.
+	ActiveMember string `json:"activeMember,omitempty"`
+
+	// Failover - failover in progress in active-backup mode, the backup is
+	// only promoted once the former active member is fenced
+	Failover *OVNDBClusterFailoverStatus `json:"failover,omitempty"`
+
+	// IntegrityCheck - result of the last database integrity check
+	IntegrityCheck *OVNDBClusterIntegrityStatus `json:"integrityCheck,omitempty"`
+
+	// LogLevels - effective console log levels per pod, e.g. "info raft:dbg".
+	// They are read back periodically to detect manual changes.
+	LogLevels map[string]PodLogLevels `json:"logLevels,omitempty"`
+
+	// Versions - observed ovsdb-server and OVN versions of the database pods
+	Versions []ComponentVersion `json:"versions,omitempty"`
+
+	// Options - values of the managed NB_Global or SB_Global options as read
+	// back from the database
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// OVNDBClusterFailoverStatus is a failover from the active member to a backup member
+type OVNDBClusterFailoverStatus struct {
+	// From - former active member
+	From string `json:"from"`
+
+	// FromUID - UID of the pod of the former active member, the backup is
+	// promoted once this pod is gone
+	FromUID types.UID `json:"fromUID,omitempty"`
+
+	// To - backup member promoted to active
+	To string `json:"to"`
+
+	// StartTime - time the failover started
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// OVNDBClusterIntegrityStatus is the result of the last database integrity check
+type OVNDBClusterIntegrityStatus struct {
+	// LastCheckTime - time of the most recent member check
+	LastCheckTime metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// Members - per cluster member results of the last integrity check
+	Members []OVNDBMemberIntegrity `json:"members,omitempty"`
+
+	// Problems found by the last integrity check
+	Problems []string `json:"problems,omitempty"`
+}
+
+// OVNDBMemberIntegrity is the integrity check result of a single cluster member
+type OVNDBMemberIntegrity struct {
+	// Name of the pod running the member
+	Name string `json:"name"`
+
+	// CheckTime - time the member was checked
+	CheckTime metav1.Time `json:"checkTime,omitempty"`
+
+	// Valid - the database file passed ovsdb-tool check-cluster
+	Valid bool `json:"valid"`
+
+	// AppliedIndex - index of the last raft log entry applied to the database
+	AppliedIndex int64 `json:"appliedIndex,omitempty"`
+
+	// Checksum of the database contents at AppliedIndex
+	Checksum string `json:"checksum,omitempty"`
+
+	// Error - reason the check failed for this member
+	Error string `json:"error,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -205,6 +438,40 @@ func (instance OVNDBCluster) RbacResourceName() string {
 	return "ovncluster-" + instance.Name
 }
 
+// GetReplicas - returns the number of replicas, the fields are unset if the
+// defaulting webhook is disabled
+func (spec OVNDBClusterSpecCore) GetReplicas() int32 {
+	if spec.Replicas == nil {
+		return legacyDefaults.DBReplicas
+	}
+	return *spec.Replicas
+}
+
+// GetElectionTimer - returns the election timer
+func (spec OVNDBClusterSpecCore) GetElectionTimer() int32 {
+	if spec.ElectionTimer == 0 {
+		return legacyDefaults.ElectionTimer
+	}
+	return spec.ElectionTimer
+}
+
+// GetInactivityProbe - returns the inactivity probe, 0 disables it
+func (spec OVNDBClusterSpecCore) GetInactivityProbe() int32 {
+	if spec.InactivityProbe == nil {
+		return legacyDefaults.InactivityProbe
+	}
+	return *spec.InactivityProbe
+}
+
+// GetProbeIntervalToActive - returns the probe interval to the active
+// member, 0 disables it
+func (spec OVNDBClusterSpecCore) GetProbeIntervalToActive() int32 {
+	if spec.ProbeIntervalToActive == nil {
+		return legacyDefaults.ProbeIntervalToActive
+	}
+	return *spec.ProbeIntervalToActive
+}
+
 // GetInternalEndpoint - return the DNS name that openshift coreDNS can resolve
 func (instance OVNDBCluster) GetInternalEndpoint() (string, error) {
 	if instance.Status.InternalDBAddress == "" {
@@ -23,7 +23,13 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -79,7 +85,25 @@ func (spec *OVNDBClusterSpec) Default() {
 
 // Default - set defaults for this OVNDBCluster core spec (this version is called by OpenStackControlplane webhooks)
 func (spec *OVNDBClusterSpecCore) Default() {
-	// nothing here yet
+	if spec.Mode == "" {
+		spec.Mode = DBModeClustered
+	}
+
+	// the profile only fills the fields which are not set explicitly, the
+	// legacy defaults cover custom profiles and settings a profile lacks
+	if profile, ok := GetProfileDefaults(spec.Profile); ok {
+		spec.applyProfile(profile)
+	}
+	spec.applyProfile(legacyDefaults)
+}
+
+// applyProfile - sets the unset fields to the values of the profile
+func (spec *OVNDBClusterSpecCore) applyProfile(profile ProfileDefaults) {
+	defaultInt32Ptr(&spec.Replicas, profile.DBReplicas)
+	defaultInt32(&spec.ElectionTimer, profile.ElectionTimer)
+	defaultInt32Ptr(&spec.InactivityProbe, profile.InactivityProbe)
+	defaultInt32Ptr(&spec.ProbeIntervalToActive, profile.ProbeIntervalToActive)
+	defaultResources(&spec.Resources, profile.DBResources)
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
@@ -91,15 +115,87 @@ var _ webhook.Validator = &OVNDBCluster{}
 func (r *OVNDBCluster) ValidateCreate() (admission.Warnings, error) {
 	ovndbclusterlog.Info("validate create", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object creation.
+	allErrs := r.Spec.ValidateCreate(field.NewPath("spec"))
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "ovn.openstack.org", Kind: "OVNDBCluster"},
+			r.Name, allErrs)
+	}
 	return nil, nil
 }
 
+// ValidateCreate - validates the OVNDBCluster core spec on creation (this version is called by OpenStackControlplane webhooks)
+func (spec *OVNDBClusterSpecCore) ValidateCreate(basePath *field.Path) field.ErrorList {
+	return spec.validate(basePath)
+}
+
+// ValidateUpdate - validates the OVNDBCluster core spec on update (this version is called by OpenStackControlplane webhooks)
+func (spec *OVNDBClusterSpecCore) ValidateUpdate(old OVNDBClusterSpecCore, basePath *field.Path) field.ErrorList {
+	return spec.validate(basePath)
+}
+
+// Validate - validates the extraArgs against the allow-lists. They are rendered
+// into the scripts of the pods, the controller validates them as well in case
+// the webhooks are not enabled.
+func (args *OVNDBClusterExtraArgs) Validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validateExtraArgs(
+		path.Child("serverOptions"), args.ServerOptions, OVSDBServerAllowedOptions)...)
+	allErrs = append(allErrs, validateUnixctlCommands(
+		path.Child("unixctl"), args.Unixctl, OVSDBServerAllowedUnixctlCommands)...)
+
+	return allErrs
+}
+
+func (spec *OVNDBClusterSpecCore) validate(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// standalone and active-backup mode run a fixed number of ovsdb-servers
+	if spec.Replicas != nil {
+		replicas := *spec.Replicas
+		switch spec.Mode {
+		case DBModeStandalone:
+			if replicas > 1 {
+				allErrs = append(allErrs, field.Invalid(
+					basePath.Child("replicas"), replicas,
+					fmt.Sprintf("must be 0 or 1 in %s mode", DBModeStandalone)))
+			}
+		case DBModeActiveBackup:
+			if replicas != 0 && replicas != 2 {
+				allErrs = append(allErrs, field.Invalid(
+					basePath.Child("replicas"), replicas,
+					fmt.Sprintf("must be 0 or 2 in %s mode", DBModeActiveBackup)))
+			}
+		}
+	}
+
+	allErrs = append(allErrs, spec.ExtraArgs.Validate(basePath.Child("extraArgs"))...)
+	allErrs = append(allErrs, validateLogLevels(basePath, spec.LogLevel, spec.LogModules)...)
+	allErrs = append(allErrs, validateGlobalOptions(basePath.Child("options"), spec.Options)...)
+	allErrs = append(allErrs, validatePodDisruptionBudget(
+		basePath.Child("override", "podDisruptionBudget"), spec.Override.PodDisruptionBudget)...)
+	allErrs = append(allErrs, validateWorkloadOverride(
+		basePath.Child("override", "statefulSet"), spec.Override.StatefulSet, &appsv1.StatefulSet{}, statefulSetOverrideFields)...)
+
+	return allErrs
+}
+
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *OVNDBCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	ovndbclusterlog.Info("validate update", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object update.
+	oldInstance, ok := old.(*OVNDBCluster)
+	if !ok || oldInstance == nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("unable to convert existing object"))
+	}
+
+	allErrs := r.Spec.ValidateUpdate(oldInstance.Spec.OVNDBClusterSpecCore, field.NewPath("spec"))
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "ovn.openstack.org", Kind: "OVNDBCluster"},
+			r.Name, allErrs)
+	}
 	return nil, nil
 }
 
@@ -22,6 +22,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
@@ -33,6 +34,16 @@ const (
 	ServiceNameOVNNorthd = "ovn-northd"
 	// TODO: remove when all external consumers switch to ServiceNameOVNNorthd
 	ServiceNameOvnNorthd = "ovn-northd"
+
+	// NThreadsModeFixed - ovn-northd runs with NThreads threads
+	NThreadsModeFixed = "fixed"
+	// NThreadsModeAuto - the number of threads is derived from the CPU allocation
+	NThreadsModeAuto = "auto"
+
+	// NThreadsMaximum - maximum number of threads supported by ovn-northd
+	NThreadsMaximum = 256
+	// OVNNorthdReplicasMaximum - maximum number of ovn-northd replicas
+	OVNNorthdReplicasMaximum = 32
 )
 
 // OVNNorthdSpec defines the desired state of OVNNorthd
@@ -48,21 +59,53 @@ type OVNNorthdSpec struct {
 type OVNNorthdSpecCore struct {
 
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:default=1
+	// +kubebuilder:validation:Enum=small;medium;large;custom
+	// Profile - sizing profile which sets replicas, nThreads and resources unless
+	// they are set explicitly
+	Profile string `json:"profile,omitempty"`
+
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Maximum=32
 	// +kubebuilder:validation:Minimum=0
-	// Replicas of OVN Northd to run
+	// Replicas of OVN Northd to run (defaults to the profile value or 1)
 	Replicas *int32 `json:"replicas"`
 
 	// +kubebuilder:validation:Optional
 	// NodeSelector to target subset of worker nodes running this service
 	NodeSelector *map[string]string `json:"nodeSelector,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// Tolerations - allow the pods to be scheduled on tainted nodes, e.g.
+	// control-plane or networker nodes
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PriorityClassName - priority class of the pods
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TopologySpreadConstraints - spread the pods across topology domains,
+	// e.g. topology.kubernetes.io/zone. The pods of the service are selected if
+	// no labelSelector is given.
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=preferred
+	// +kubebuilder:validation:Enum=preferred;required
+	// AntiAffinity - preferred spreads the pods across nodes if possible,
+	// required never schedules two pods of the service on the same node
+	AntiAffinity string `json:"antiAffinity,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=info
 	// LogLevel - Set log level info, dbg, emer etc
 	LogLevel string `json:"logLevel,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// LogModules - Per module log levels, e.g. raft: dbg. Modules not listed use
+	// LogLevel. Changes are applied to the running processes without a restart
+	LogModules map[string]string `json:"logModules,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// Resources - Compute Resources required by this service (Limits/Requests).
 	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
@@ -74,9 +117,112 @@ type OVNNorthdSpecCore struct {
 	TLS tls.SimpleService `json:"tls,omitempty"`
 
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:default=1
-	// NThreads sets number of threads used for building logical flows
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=256
+	// NThreads sets number of threads used for building logical flows (defaults to the profile value or 1)
 	NThreads *int32 `json:"nThreads"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=fixed
+	// +kubebuilder:validation:Enum=fixed;auto
+	// NThreadsMode - fixed runs NThreads threads. auto derives the number of
+	// threads from the CPU limit of the ovn-northd container, or its CPU request
+	// if no limit is set, one thread per full CPU, bounded by NThreadsMin and
+	// NThreadsMax. NThreads is used if neither is set.
+	NThreadsMode string `json:"nThreadsMode,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=256
+	// NThreadsMin - lower bound of the number of threads in auto mode
+	NThreadsMin *int32 `json:"nThreadsMin,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=256
+	// NThreadsMax - upper bound of the number of threads in auto mode
+	NThreadsMax *int32 `json:"nThreadsMax,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// Metrics - sidecar sampling the ovn-northd loop and processing times
+	Metrics OVNNorthdMetricsSpec `json:"metrics,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraArgs - additional ovn-northd command line options in the form
+	// --option or --option=value. Only a subset of the ovn-northd options is
+	// accepted, see OVNNorthdAllowedOptions.
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// Probes - timings of the readiness and liveness probes of ovn-northd
+	Probes OVNNorthdProbesSpec `json:"probes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Override, provides the ability to override the generated manifest of several child resources.
+	Override OVNNorthdOverrideSpec `json:"override,omitempty"`
+}
+
+// OVNNorthdProbesSpec defines the timings of the ovn-northd probes. The
+// liveness probe requires ovn-northd to answer over its unixctl socket, the
+// readiness probe additionally checks its status and the NB and SB
+// connections.
+type OVNNorthdProbesSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=0
+	// InitialDelaySeconds - delay before the first probe after the container started
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// PeriodSeconds - how often the probes run
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=2
+	// TimeoutSeconds - timeout of the probes. The ovn-appctl calls of a probe
+	// get one second less, so that they fail with an error message before the
+	// kubelet kills the probe.
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// FailureThreshold - consecutive failures after which the pod is
+	// considered not ready or gets restarted
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// OVNNorthdMetricsSpec defines the metrics sidecar of ovn-northd. The sidecar
+// periodically samples the ovn-northd stopwatches, which the controller
+// reports in the status.
+type OVNNorthdMetricsSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - run the metrics sidecar
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=5
+	// IntervalSeconds - how often the stopwatches are sampled
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+}
+
+// OVNNorthdOverrideSpec to override the generated manifest of several child resources.
+type OVNNorthdOverrideSpec struct {
+	// Override configuration for the PodDisruptionBudget of the northd pods.
+	// By default one pod may be unavailable if there is more than one replica.
+	PodDisruptionBudget *PodDisruptionBudgetOverrideSpec `json:"podDisruptionBudget,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Override for the generated Deployment, applied as strategic merge patch.
+	Deployment *runtime.RawExtension `json:"deployment,omitempty"`
 }
 
 // OVNNorthdStatus defines the observed state of OVNNorthd
@@ -89,6 +235,55 @@ type OVNNorthdStatus struct {
 
 	//ObservedGeneration - the most recent generation observed for this service. If the observed generation is less than the spec generation, then the controller has not processed the latest changes.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LogLevels - effective console log levels per pod, e.g. "info jsonrpc:info".
+	// They are read back periodically to detect manual changes.
+	LogLevels map[string]PodLogLevels `json:"logLevels,omitempty"`
+
+	// Versions - observed ovn-northd versions
+	Versions []ComponentVersion `json:"versions,omitempty"`
+
+	// ActiveInstance - pod of the ovn-northd instance holding the OVN_Southbound lock
+	ActiveInstance string `json:"activeInstance,omitempty"`
+
+	// StandbyInstances - pods of the ovn-northd instances waiting for the lock
+	StandbyInstances []string `json:"standbyInstances,omitempty"`
+
+	// NBEndpoint - OVN_Northbound endpoint the deployment is configured with
+	NBEndpoint string `json:"nbEndpoint,omitempty"`
+
+	// SBEndpoint - OVN_Southbound endpoint the deployment is configured with
+	SBEndpoint string `json:"sbEndpoint,omitempty"`
+
+	// Map of hashes to track e.g. job status
+	Hash map[string]string `json:"hash,omitempty"`
+
+	// NThreads - number of threads the deployment is configured with
+	NThreads int32 `json:"nThreads,omitempty"`
+
+	// Performance - loop and processing times per pod, reported if the
+	// metrics sidecar is enabled
+	Performance map[string]OVNNorthdPerformance `json:"performance,omitempty"`
+}
+
+// OVNNorthdPerformance - long term averages and maxima of the ovn-northd
+// stopwatches of a pod
+type OVNNorthdPerformance struct {
+	// LoopTime - duration of an iteration of the main loop
+	LoopTime metav1.Duration `json:"loopTime,omitempty"`
+
+	// LoopTimeMax - longest iteration of the main loop
+	LoopTimeMax metav1.Duration `json:"loopTimeMax,omitempty"`
+
+	// ProcessingTime - duration of processing the OVN_Northbound changes,
+	// only the active instance does any processing
+	ProcessingTime metav1.Duration `json:"processingTime,omitempty"`
+
+	// ProcessingTimeMax - longest processing of the OVN_Northbound changes
+	ProcessingTimeMax metav1.Duration `json:"processingTimeMax,omitempty"`
+
+	// Samples - number of main loop iterations measured
+	Samples int64 `json:"samples,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -118,6 +313,15 @@ func init() {
 	SchemeBuilder.Register(&OVNNorthd{}, &OVNNorthdList{})
 }
 
+// GetReplicas - returns the number of replicas, the field is unset if the
+// defaulting webhook is disabled
+func (spec OVNNorthdSpecCore) GetReplicas() int32 {
+	if spec.Replicas == nil {
+		return legacyDefaults.NorthdReplicas
+	}
+	return *spec.Replicas
+}
+
 // IsReady - returns true if service is ready to server requests
 func (instance OVNNorthd) IsReady() bool {
 	// Ready when:
@@ -23,7 +23,13 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -74,7 +80,19 @@ func (spec *OVNNorthdSpec) Default() {
 
 // Default - set defaults for this OVNNorthd core spec (this version is called by OpenStackControlplane webhooks)
 func (spec *OVNNorthdSpecCore) Default() {
-	// nothing here yet
+	// the profile only fills the fields which are not set explicitly, the
+	// legacy defaults cover custom profiles and settings a profile lacks
+	if profile, ok := GetProfileDefaults(spec.Profile); ok {
+		spec.applyProfile(profile)
+	}
+	spec.applyProfile(legacyDefaults)
+}
+
+// applyProfile - sets the unset fields to the values of the profile
+func (spec *OVNNorthdSpecCore) applyProfile(profile ProfileDefaults) {
+	defaultInt32Ptr(&spec.Replicas, profile.NorthdReplicas)
+	defaultInt32Ptr(&spec.NThreads, profile.NorthdNThreads)
+	defaultResources(&spec.Resources, profile.NorthdResources)
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
@@ -86,7 +104,12 @@ var _ webhook.Validator = &OVNNorthd{}
 func (r *OVNNorthd) ValidateCreate() (admission.Warnings, error) {
 	ovnnorthdlog.Info("validate create", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object creation.
+	allErrs := r.Spec.ValidateCreate(field.NewPath("spec"))
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "ovn.openstack.org", Kind: "OVNNorthd"},
+			r.Name, allErrs)
+	}
 	return nil, nil
 }
 
@@ -94,8 +117,77 @@ func (r *OVNNorthd) ValidateCreate() (admission.Warnings, error) {
 func (r *OVNNorthd) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	ovnnorthdlog.Info("validate update", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object update.
-	return nil, nil
+	oldInstance, ok := old.(*OVNNorthd)
+	if !ok || oldInstance == nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("unable to convert existing object"))
+	}
+
+	warnings := r.Spec.UpdateWarnings(oldInstance.Spec.OVNNorthdSpecCore, field.NewPath("spec"))
+	allErrs := r.Spec.ValidateUpdate(oldInstance.Spec.OVNNorthdSpecCore, field.NewPath("spec"))
+	if len(allErrs) != 0 {
+		return warnings, apierrors.NewInvalid(
+			schema.GroupKind{Group: "ovn.openstack.org", Kind: "OVNNorthd"},
+			r.Name, allErrs)
+	}
+	return warnings, nil
+}
+
+// ValidateCreate - validates the OVNNorthd core spec on creation (this version is called by OpenStackControlplane webhooks)
+func (spec *OVNNorthdSpecCore) ValidateCreate(basePath *field.Path) field.ErrorList {
+	return spec.validate(basePath)
+}
+
+// ValidateUpdate - validates the OVNNorthd core spec on update (this version is called by OpenStackControlplane webhooks)
+func (spec *OVNNorthdSpecCore) ValidateUpdate(old OVNNorthdSpecCore, basePath *field.Path) field.ErrorList {
+	return spec.validate(basePath)
+}
+
+func (spec *OVNNorthdSpecCore) validate(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validateExtraArgs(
+		basePath.Child("extraArgs"), spec.ExtraArgs, OVNNorthdAllowedOptions)...)
+	allErrs = append(allErrs, validateLogLevels(basePath, spec.LogLevel, spec.LogModules)...)
+	if spec.Replicas != nil && (*spec.Replicas < 0 || *spec.Replicas > OVNNorthdReplicasMaximum) {
+		allErrs = append(allErrs, field.Invalid(basePath.Child("replicas"), *spec.Replicas,
+			fmt.Sprintf("must be between 0 and %d", OVNNorthdReplicasMaximum)))
+	}
+	allErrs = append(allErrs, validateNThreads(basePath.Child("nThreads"), spec.NThreads)...)
+	allErrs = append(allErrs, validateNThreads(basePath.Child("nThreadsMin"), spec.NThreadsMin)...)
+	allErrs = append(allErrs, validateNThreads(basePath.Child("nThreadsMax"), spec.NThreadsMax)...)
+	if spec.NThreadsMin != nil && spec.NThreadsMax != nil && *spec.NThreadsMin > *spec.NThreadsMax {
+		allErrs = append(allErrs, field.Invalid(basePath.Child("nThreadsMin"), *spec.NThreadsMin,
+			fmt.Sprintf("must not be greater than nThreadsMax (%d)", *spec.NThreadsMax)))
+	}
+	allErrs = append(allErrs, validatePodDisruptionBudget(
+		basePath.Child("override", "podDisruptionBudget"), spec.Override.PodDisruptionBudget)...)
+	allErrs = append(allErrs, validateWorkloadOverride(
+		basePath.Child("override", "deployment"), spec.Override.Deployment, &appsv1.Deployment{}, deploymentOverrideFields)...)
+
+	return allErrs
+}
+
+// validateNThreads - validate that a number of threads is supported by ovn-northd
+func validateNThreads(path *field.Path, nThreads *int32) field.ErrorList {
+	if nThreads == nil || (*nThreads >= 1 && *nThreads <= NThreadsMaximum) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, *nThreads,
+		fmt.Sprintf("must be between 1 and %d", NThreadsMaximum))}
+}
+
+// UpdateWarnings - returns warnings about disruptive changes of the OVNNorthd core spec (this version is called by OpenStackControlplane webhooks)
+func (spec *OVNNorthdSpecCore) UpdateWarnings(old OVNNorthdSpecCore, basePath *field.Path) admission.Warnings {
+	var warnings admission.Warnings
+
+	if spec.Replicas != nil && *spec.Replicas == 0 && (old.Replicas == nil || *old.Replicas != 0) {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: scaling ovn-northd to 0 stops the translation of the northbound database, "+
+				"changes are not applied to the chassis until it is scaled up again",
+			basePath.Child("replicas")))
+	}
+
+	return warnings
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -0,0 +1,227 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ProfileSmall - single replica databases, sized for test and edge deployments
+	ProfileSmall = "small"
+	// ProfileMedium - clustered databases for deployments of up to ~100 chassis
+	ProfileMedium = "medium"
+	// ProfileLarge - clustered databases and longer timers for large SB databases
+	ProfileLarge = "large"
+	// ProfileCustom - no profile, unset fields get the regular defaults
+	ProfileCustom = "custom"
+)
+
+// Profiles - sizing profiles which expand into tuned settings
+var Profiles = []string{ProfileSmall, ProfileMedium, ProfileLarge}
+
+// ProfileDefaults - settings a sizing profile expands into. Every value is
+// only applied to fields which are not set explicitly.
+type ProfileDefaults struct {
+	DBReplicas            int32
+	ElectionTimer         int32
+	InactivityProbe       int32
+	ProbeIntervalToActive int32
+	DBResources           corev1.ResourceList
+
+	NorthdReplicas  int32
+	NorthdNThreads  int32
+	NorthdResources corev1.ResourceList
+
+	RemoteProbeInterval int32
+	ControllerResources corev1.ResourceList
+}
+
+// legacyDefaults - applied to the fields which are still unset after the
+// profile was expanded, these were the CRD defaults before profiles existed
+var legacyDefaults = ProfileDefaults{
+	DBReplicas:            1,
+	ElectionTimer:         10000,
+	InactivityProbe:       60000,
+	ProbeIntervalToActive: 60000,
+	NorthdReplicas:        1,
+	NorthdNThreads:        1,
+}
+
+// builtinProfiles - the vetted profile definitions, each value can be
+// overridden via the OVN_PROFILE_<PROFILE>_<SETTING> environment variables
+var builtinProfiles = map[string]ProfileDefaults{
+	ProfileSmall: {
+		DBReplicas:            1,
+		ElectionTimer:         10000,
+		InactivityProbe:       60000,
+		ProbeIntervalToActive: 60000,
+		DBResources:           resourceList("100m", "256Mi"),
+		NorthdReplicas:        1,
+		NorthdNThreads:        1,
+		NorthdResources:       resourceList("100m", "256Mi"),
+		RemoteProbeInterval:   60000,
+		ControllerResources:   resourceList("100m", "256Mi"),
+	},
+	ProfileMedium: {
+		DBReplicas:            3,
+		ElectionTimer:         10000,
+		InactivityProbe:       60000,
+		ProbeIntervalToActive: 60000,
+		DBResources:           resourceList("500m", "1Gi"),
+		NorthdReplicas:        1,
+		NorthdNThreads:        2,
+		NorthdResources:       resourceList("2", "1Gi"),
+		RemoteProbeInterval:   60000,
+		ControllerResources:   resourceList("200m", "512Mi"),
+	},
+	ProfileLarge: {
+		DBReplicas:            3,
+		ElectionTimer:         16000,
+		InactivityProbe:       180000,
+		ProbeIntervalToActive: 180000,
+		DBResources:           resourceList("2", "4Gi"),
+		NorthdReplicas:        2,
+		NorthdNThreads:        4,
+		NorthdResources:       resourceList("4", "4Gi"),
+		RemoteProbeInterval:   180000,
+		ControllerResources:   resourceList("500m", "1Gi"),
+	},
+}
+
+var profileDefaults = builtinProfiles
+
+// log is for logging the profile setup.
+var profilelog = logf.Log.WithName("profile")
+
+// SetupProfileDefaults - initialize the sizing profile definitions for use with either internal or external webhooks
+func SetupProfileDefaults(profiles map[string]ProfileDefaults) {
+	profileDefaults = profiles
+	profilelog.Info("Sizing profiles initialized", "profiles", profiles)
+}
+
+// GetProfileDefaults - returns the settings of the given profile, false for
+// custom, empty or unknown profiles
+func GetProfileDefaults(profile string) (ProfileDefaults, bool) {
+	p, ok := profileDefaults[profile]
+	return p, ok
+}
+
+// profileDefaultsFromEnv - returns the builtin profiles with the values
+// overridden by the OVN_PROFILE_<PROFILE>_<SETTING> environment variables.
+// Values which can not be parsed are ignored.
+func profileDefaultsFromEnv() map[string]ProfileDefaults {
+	profiles := map[string]ProfileDefaults{}
+	for _, name := range Profiles {
+		p := builtinProfiles[name]
+		prefix := "OVN_PROFILE_" + strings.ToUpper(name) + "_"
+
+		p.DBReplicas = envInt32(prefix+"DB_REPLICAS", p.DBReplicas)
+		p.ElectionTimer = envInt32(prefix+"ELECTION_TIMER", p.ElectionTimer)
+		p.InactivityProbe = envInt32(prefix+"INACTIVITY_PROBE", p.InactivityProbe)
+		p.ProbeIntervalToActive = envInt32(prefix+"PROBE_INTERVAL_TO_ACTIVE", p.ProbeIntervalToActive)
+		p.DBResources = envResourceList(prefix+"DB", p.DBResources)
+		p.NorthdReplicas = envInt32(prefix+"NORTHD_REPLICAS", p.NorthdReplicas)
+		p.NorthdNThreads = envInt32(prefix+"NORTHD_NTHREADS", p.NorthdNThreads)
+		p.NorthdResources = envResourceList(prefix+"NORTHD", p.NorthdResources)
+		p.RemoteProbeInterval = envInt32(prefix+"REMOTE_PROBE_INTERVAL", p.RemoteProbeInterval)
+		p.ControllerResources = envResourceList(prefix+"CONTROLLER", p.ControllerResources)
+
+		profiles[name] = p
+	}
+	return profiles
+}
+
+// envInt32 - returns the value of the environment variable or def if it is
+// unset or not a valid int32
+func envInt32(name string, def int32) int32 {
+	value := util.GetEnvVar(name, "")
+	if value == "" {
+		return def
+	}
+	i, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		profilelog.Info(fmt.Sprintf("Ignoring invalid value of %s: %s", name, value))
+		return def
+	}
+	return int32(i)
+}
+
+// envResourceList - returns the requests with the cpu and memory quantities
+// overridden by the <prefix>_CPU and <prefix>_MEMORY environment variables
+func envResourceList(prefix string, def corev1.ResourceList) corev1.ResourceList {
+	list := def.DeepCopy()
+	for name, suffix := range map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    "_CPU",
+		corev1.ResourceMemory: "_MEMORY",
+	} {
+		value := util.GetEnvVar(prefix+suffix, "")
+		if value == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			profilelog.Info(fmt.Sprintf("Ignoring invalid value of %s: %s", prefix+suffix, value))
+			continue
+		}
+		if list == nil {
+			list = corev1.ResourceList{}
+		}
+		list[name] = q
+	}
+	return list
+}
+
+// resourceList - returns a ResourceList with the given cpu and memory
+func resourceList(cpu string, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+// defaultInt32 - sets *field to value if it is unset, only used for fields
+// where 0 is not a valid value
+func defaultInt32(field *int32, value int32) {
+	if *field == 0 {
+		*field = value
+	}
+}
+
+// defaultInt32Ptr - sets *field to value if it is nil and value is set, an
+// explicit 0 is kept
+func defaultInt32Ptr(field **int32, value int32) {
+	if *field == nil && value != 0 {
+		v := value
+		*field = &v
+	}
+}
+
+// defaultResources - sets the resource requests if neither requests nor
+// limits are set
+func defaultResources(resources *corev1.ResourceRequirements, requests corev1.ResourceList) {
+	if len(resources.Requests) == 0 && len(resources.Limits) == 0 && len(requests) > 0 {
+		resources.Requests = requests.DeepCopy()
+	}
+}
@@ -23,9 +23,26 @@ package v1beta1
 import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentVersion) DeepCopyInto(out *ComponentVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentVersion.
+func (in *ComponentVersion) DeepCopy() *ComponentVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVNController) DeepCopyInto(out *OVNController) {
 	*out = *in
@@ -53,6 +70,41 @@ func (in *OVNController) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerContainerResources) DeepCopyInto(out *OVNControllerContainerResources) {
+	*out = *in
+	if in.OVSDBServer != nil {
+		in, out := &in.OVSDBServer, &out.OVSDBServer
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OVSVswitchd != nil {
+		in, out := &in.OVSVswitchd, &out.OVSVswitchd
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OVNController != nil {
+		in, out := &in.OVNController, &out.OVNController
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigAgent != nil {
+		in, out := &in.ConfigAgent, &out.ConfigAgent
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerContainerResources.
+func (in *OVNControllerContainerResources) DeepCopy() *OVNControllerContainerResources {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerContainerResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVNControllerDefaults) DeepCopyInto(out *OVNControllerDefaults) {
 	*out = *in
@@ -100,6 +152,117 @@ func (in *OVNControllerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerNodeGroup) DeepCopyInto(out *OVNControllerNodeGroup) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NicMappings != nil {
+		in, out := &in.NicMappings, &out.NicMappings
+		*out = new(map[string]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(map[string]string, len(*in))
+			for key, val := range *in {
+				(*out)[key] = val
+			}
+		}
+	}
+	if in.ExternalIDS != nil {
+		in, out := &in.ExternalIDS, &out.ExternalIDS
+		*out = new(OVSExternalIDsOverride)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerNodeGroup.
+func (in *OVNControllerNodeGroup) DeepCopy() *OVNControllerNodeGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerNodeGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerNodeGroupStatus) DeepCopyInto(out *OVNControllerNodeGroupStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerNodeGroupStatus.
+func (in *OVNControllerNodeGroupStatus) DeepCopy() *OVNControllerNodeGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerNodeGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerNodeStatus) DeepCopyInto(out *OVNControllerNodeStatus) {
+	*out = *in
+	if in.BridgeMappings != nil {
+		in, out := &in.BridgeMappings, &out.BridgeMappings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigAppliedTime != nil {
+		in, out := &in.ConfigAppliedTime, &out.ConfigAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExternalIDsDrift != nil {
+		in, out := &in.ExternalIDsDrift, &out.ExternalIDsDrift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRemediationTime != nil {
+		in, out := &in.LastRemediationTime, &out.LastRemediationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerNodeStatus.
+func (in *OVNControllerNodeStatus) DeepCopy() *OVNControllerNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNControllerOverrideSpec) DeepCopyInto(out *OVNControllerOverrideSpec) {
+	*out = *in
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OVSDaemonSet != nil {
+		in, out := &in.OVSDaemonSet, &out.OVSDaemonSet
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerOverrideSpec.
+func (in *OVNControllerOverrideSpec) DeepCopy() *OVNControllerOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNControllerOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVNControllerSpec) DeepCopyInto(out *OVNControllerSpec) {
 	*out = *in
@@ -120,6 +283,20 @@ func (in *OVNControllerSpec) DeepCopy() *OVNControllerSpec {
 func (in *OVNControllerSpecCore) DeepCopyInto(out *OVNControllerSpecCore) {
 	*out = *in
 	in.ExternalIDS.DeepCopyInto(&out.ExternalIDS)
+	if in.ExtraExternalIDs != nil {
+		in, out := &in.ExtraExternalIDs, &out.ExtraExternalIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.OVSOtherConfig != nil {
+		in, out := &in.OVSOtherConfig, &out.OVSOtherConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.NicMappings != nil {
 		in, out := &in.NicMappings, &out.NicMappings
 		*out = make(map[string]string, len(*in))
@@ -128,6 +305,7 @@ func (in *OVNControllerSpecCore) DeepCopyInto(out *OVNControllerSpecCore) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	in.ContainerResources.DeepCopyInto(&out.ContainerResources)
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = new(map[string]string)
@@ -139,7 +317,22 @@ func (in *OVNControllerSpecCore) DeepCopyInto(out *OVNControllerSpecCore) {
 			}
 		}
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.TLS.DeepCopyInto(&out.TLS)
+	in.Override.DeepCopyInto(&out.Override)
+	if in.NodeGroups != nil {
+		in, out := &in.NodeGroups, &out.NodeGroups
+		*out = make([]OVNControllerNodeGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerSpecCore.
@@ -185,6 +378,23 @@ func (in *OVNControllerStatus) DeepCopyInto(out *OVNControllerStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]ComponentVersion, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeGroups != nil {
+		in, out := &in.NodeGroups, &out.NodeGroups
+		*out = make([]OVNControllerNodeGroupStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]OVNControllerNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNControllerStatus.
@@ -224,6 +434,31 @@ func (in *OVNDBCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterContainerResources) DeepCopyInto(out *OVNDBClusterContainerResources) {
+	*out = *in
+	if in.OVSDBServer != nil {
+		in, out := &in.OVSDBServer, &out.OVSDBServer
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IntegrityCheck != nil {
+		in, out := &in.IntegrityCheck, &out.IntegrityCheck
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterContainerResources.
+func (in *OVNDBClusterContainerResources) DeepCopy() *OVNDBClusterContainerResources {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterContainerResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVNDBClusterDefaults) DeepCopyInto(out *OVNDBClusterDefaults) {
 	*out = *in
@@ -239,6 +474,93 @@ func (in *OVNDBClusterDefaults) DeepCopy() *OVNDBClusterDefaults {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterExtraArgs) DeepCopyInto(out *OVNDBClusterExtraArgs) {
+	*out = *in
+	if in.ServerOptions != nil {
+		in, out := &in.ServerOptions, &out.ServerOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Unixctl != nil {
+		in, out := &in.Unixctl, &out.Unixctl
+		*out = make([]UnixctlCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Remote.DeepCopyInto(&out.Remote)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterExtraArgs.
+func (in *OVNDBClusterExtraArgs) DeepCopy() *OVNDBClusterExtraArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterExtraArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterFailoverStatus) DeepCopyInto(out *OVNDBClusterFailoverStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterFailoverStatus.
+func (in *OVNDBClusterFailoverStatus) DeepCopy() *OVNDBClusterFailoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterFailoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterIntegrityCheckSpec) DeepCopyInto(out *OVNDBClusterIntegrityCheckSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterIntegrityCheckSpec.
+func (in *OVNDBClusterIntegrityCheckSpec) DeepCopy() *OVNDBClusterIntegrityCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterIntegrityCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBClusterIntegrityStatus) DeepCopyInto(out *OVNDBClusterIntegrityStatus) {
+	*out = *in
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]OVNDBMemberIntegrity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Problems != nil {
+		in, out := &in.Problems, &out.Problems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterIntegrityStatus.
+func (in *OVNDBClusterIntegrityStatus) DeepCopy() *OVNDBClusterIntegrityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBClusterIntegrityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVNDBClusterList) DeepCopyInto(out *OVNDBClusterList) {
 	*out = *in
@@ -279,6 +601,16 @@ func (in *OVNDBClusterOverrideSpec) DeepCopyInto(out *OVNDBClusterOverrideSpec)
 		*out = new(service.OverrideSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetOverrideSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StatefulSet != nil {
+		in, out := &in.StatefulSet, &out.StatefulSet
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterOverrideSpec.
@@ -326,9 +658,50 @@ func (in *OVNDBClusterSpecCore) DeepCopyInto(out *OVNDBClusterSpecCore) {
 			}
 		}
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LogModules != nil {
+		in, out := &in.LogModules, &out.LogModules
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InactivityProbe != nil {
+		in, out := &in.InactivityProbe, &out.InactivityProbe
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProbeIntervalToActive != nil {
+		in, out := &in.ProbeIntervalToActive, &out.ProbeIntervalToActive
+		*out = new(int32)
+		**out = **in
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	in.ContainerResources.DeepCopyInto(&out.ContainerResources)
 	in.TLS.DeepCopyInto(&out.TLS)
 	in.Override.DeepCopyInto(&out.Override)
+	out.IntegrityCheck = in.IntegrityCheck
+	in.ExtraArgs.DeepCopyInto(&out.ExtraArgs)
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterSpecCore.
@@ -374,6 +747,35 @@ func (in *OVNDBClusterStatus) DeepCopyInto(out *OVNDBClusterStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(OVNDBClusterFailoverStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IntegrityCheck != nil {
+		in, out := &in.IntegrityCheck, &out.IntegrityCheck
+		*out = new(OVNDBClusterIntegrityStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogLevels != nil {
+		in, out := &in.LogLevels, &out.LogLevels
+		*out = make(map[string]PodLogLevels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]ComponentVersion, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBClusterStatus.
@@ -386,6 +788,47 @@ func (in *OVNDBClusterStatus) DeepCopy() *OVNDBClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBMemberIntegrity) DeepCopyInto(out *OVNDBMemberIntegrity) {
+	*out = *in
+	in.CheckTime.DeepCopyInto(&out.CheckTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBMemberIntegrity.
+func (in *OVNDBMemberIntegrity) DeepCopy() *OVNDBMemberIntegrity {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBMemberIntegrity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNDBRemoteOptions) DeepCopyInto(out *OVNDBRemoteOptions) {
+	*out = *in
+	if in.Dscp != nil {
+		in, out := &in.Dscp, &out.Dscp
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNDBRemoteOptions.
+func (in *OVNDBRemoteOptions) DeepCopy() *OVNDBRemoteOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNDBRemoteOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVNNorthd) DeepCopyInto(out *OVNNorthd) {
 	*out = *in
@@ -460,6 +903,80 @@ func (in *OVNNorthdList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNNorthdMetricsSpec) DeepCopyInto(out *OVNNorthdMetricsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdMetricsSpec.
+func (in *OVNNorthdMetricsSpec) DeepCopy() *OVNNorthdMetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNNorthdMetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNNorthdOverrideSpec) DeepCopyInto(out *OVNNorthdOverrideSpec) {
+	*out = *in
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetOverrideSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdOverrideSpec.
+func (in *OVNNorthdOverrideSpec) DeepCopy() *OVNNorthdOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNNorthdOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNNorthdPerformance) DeepCopyInto(out *OVNNorthdPerformance) {
+	*out = *in
+	out.LoopTime = in.LoopTime
+	out.LoopTimeMax = in.LoopTimeMax
+	out.ProcessingTime = in.ProcessingTime
+	out.ProcessingTimeMax = in.ProcessingTimeMax
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdPerformance.
+func (in *OVNNorthdPerformance) DeepCopy() *OVNNorthdPerformance {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNNorthdPerformance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVNNorthdProbesSpec) DeepCopyInto(out *OVNNorthdProbesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdProbesSpec.
+func (in *OVNNorthdProbesSpec) DeepCopy() *OVNNorthdProbesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OVNNorthdProbesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVNNorthdSpec) DeepCopyInto(out *OVNNorthdSpec) {
 	*out = *in
@@ -495,6 +1012,27 @@ func (in *OVNNorthdSpecCore) DeepCopyInto(out *OVNNorthdSpecCore) {
 			}
 		}
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LogModules != nil {
+		in, out := &in.LogModules, &out.LogModules
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	in.TLS.DeepCopyInto(&out.TLS)
 	if in.NThreads != nil {
@@ -502,6 +1040,24 @@ func (in *OVNNorthdSpecCore) DeepCopyInto(out *OVNNorthdSpecCore) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.NThreadsMin != nil {
+		in, out := &in.NThreadsMin, &out.NThreadsMin
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NThreadsMax != nil {
+		in, out := &in.NThreadsMax, &out.NThreadsMax
+		*out = new(int32)
+		**out = **in
+	}
+	out.Metrics = in.Metrics
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Probes = in.Probes
+	in.Override.DeepCopyInto(&out.Override)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdSpecCore.
@@ -524,6 +1080,37 @@ func (in *OVNNorthdStatus) DeepCopyInto(out *OVNNorthdStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LogLevels != nil {
+		in, out := &in.LogLevels, &out.LogLevels
+		*out = make(map[string]PodLogLevels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]ComponentVersion, len(*in))
+		copy(*out, *in)
+	}
+	if in.StandbyInstances != nil {
+		in, out := &in.StandbyInstances, &out.StandbyInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Performance != nil {
+		in, out := &in.Performance, &out.Performance
+		*out = make(map[string]OVNNorthdPerformance, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVNNorthdStatus.
@@ -549,6 +1136,11 @@ func (in *OVSExternalIDs) DeepCopyInto(out *OVSExternalIDs) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.OvnRemoteProbeInterval != nil {
+		in, out := &in.OvnRemoteProbeInterval, &out.OvnRemoteProbeInterval
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSExternalIDs.
@@ -560,3 +1152,135 @@ func (in *OVSExternalIDs) DeepCopy() *OVSExternalIDs {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSExternalIDsOverride) DeepCopyInto(out *OVSExternalIDsOverride) {
+	*out = *in
+	if in.OvnAvailabilityZones != nil {
+		in, out := &in.OvnAvailabilityZones, &out.OvnAvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnableChassisAsGateway != nil {
+		in, out := &in.EnableChassisAsGateway, &out.EnableChassisAsGateway
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OvnRemoteProbeInterval != nil {
+		in, out := &in.OvnRemoteProbeInterval, &out.OvnRemoteProbeInterval
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSExternalIDsOverride.
+func (in *OVSExternalIDsOverride) DeepCopy() *OVSExternalIDsOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSExternalIDsOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetOverrideSpec) DeepCopyInto(out *PodDisruptionBudgetOverrideSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetOverrideSpec.
+func (in *PodDisruptionBudgetOverrideSpec) DeepCopy() *PodDisruptionBudgetOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodLogLevels) DeepCopyInto(out *PodLogLevels) {
+	*out = *in
+	in.CheckTime.DeepCopyInto(&out.CheckTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodLogLevels.
+func (in *PodLogLevels) DeepCopy() *PodLogLevels {
+	if in == nil {
+		return nil
+	}
+	out := new(PodLogLevels)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileDefaults) DeepCopyInto(out *ProfileDefaults) {
+	*out = *in
+	if in.DBResources != nil {
+		in, out := &in.DBResources, &out.DBResources
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.NorthdResources != nil {
+		in, out := &in.NorthdResources, &out.NorthdResources
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.ControllerResources != nil {
+		in, out := &in.ControllerResources, &out.ControllerResources
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileDefaults.
+func (in *ProfileDefaults) DeepCopy() *ProfileDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnixctlCommand) DeepCopyInto(out *UnixctlCommand) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnixctlCommand.
+func (in *UnixctlCommand) DeepCopy() *UnixctlCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(UnixctlCommand)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -16,6 +16,24 @@ limitations under the License.
 
 package controllers
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
 // fields to index to reconcile when changed
 const (
 	tlsField                = ".spec.tls.secretName"
@@ -28,3 +46,190 @@ var (
 		tlsField,
 	}
 )
+
+// dbReadyConditions - the condition reporting the availability of the
+// OVNDBCluster of each type
+var dbReadyConditions = map[string]condition.Type{
+	ovnv1.NBDBType: ovnv1.NBDBReadyCondition,
+	ovnv1.SBDBType: ovnv1.SBDBReadyCondition,
+}
+
+// dbReadyInitCondition - returns the NBDBReady or SBDBReady condition in its
+// initial state
+func dbReadyInitCondition(dbType string) *condition.Condition {
+	return condition.UnknownCondition(dbReadyConditions[dbType], condition.InitReason, ovnv1.DBReadyInitMessage, dbType)
+}
+
+// getDBClusterEndpoint - returns the OVNDBCluster of the given type and its
+// internal endpoint, and sets the NBDBReady or SBDBReady condition. An empty
+// endpoint means the component has to wait for the database; the OVNDBCluster
+// watch triggers the next reconcile once it is available.
+func getDBClusterEndpoint(
+	ctx context.Context,
+	h *helper.Helper,
+	conditions *condition.Conditions,
+	namespace string,
+	dbType string,
+) (*ovnv1.OVNDBCluster, string, error) {
+	conditionType := dbReadyConditions[dbType]
+	cluster, err := ovnv1.GetDBClusterByType(ctx, h, namespace, map[string]string{}, dbType)
+	if errors.Is(err, ovnv1.ErrDBClusterNotFound) {
+		conditions.Set(condition.FalseCondition(
+			conditionType,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.DBReadyMissingMessage,
+			dbType))
+		return nil, "", nil
+	} else if err != nil {
+		conditions.Set(condition.FalseCondition(
+			conditionType,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.DBReadyErrorMessage,
+			dbType, err.Error()))
+		return nil, "", err
+	}
+
+	endpoint, err := cluster.GetInternalEndpoint()
+	if err != nil {
+		conditions.Set(condition.FalseCondition(
+			conditionType,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.DBReadyWaitingMessage,
+			dbType, cluster.Name, err.Error()))
+		return cluster, "", nil
+	}
+	conditions.MarkTrue(conditionType, ovnv1.DBReadyMessage, dbType, cluster.Name)
+	return cluster, endpoint, nil
+}
+
+// reconcileLogLevels - apply the log levels to the running pods where the
+// effective levels differ and return the effective levels per pod. The pods
+// are only reached when the desired levels differ from the ones recorded for
+// them or they were recreated. The effective levels are read back every
+// LogLevelsCheckInterval, so levels changed manually through vlog/set are set
+// back, the returned result requeues for the next check. Pods which can't be
+// reached keep being reported as not yet configured.
+func reconcileLogLevels(
+	ctx context.Context,
+	h *helper.Helper,
+	config *rest.Config,
+	pods []corev1.Pod,
+	container string,
+	appctl string,
+	level string,
+	modules map[string]string,
+	recorded map[string]ovnv1.PodLogLevels,
+) (map[string]ovnv1.PodLogLevels, ctrl.Result) {
+	desired := ovn_common.LogLevelSpec(level, modules)
+	effective := map[string]ovnv1.PodLogLevels{}
+	result := ctrl.Result{}
+	requeueAfter := func(next time.Duration) {
+		if result.RequeueAfter == 0 || next < result.RequeueAfter {
+			result.RequeueAfter = next
+		}
+	}
+
+	now := time.Now()
+	for i, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		var previous *ovnv1.PodLogLevels
+		if p, ok := recorded[pod.Name]; ok {
+			previous = &p
+		}
+		due, next := ovn_common.LogLevelsCheckDue(&pods[i], previous, desired, now)
+		if !due {
+			effective[pod.Name] = *previous
+			requeueAfter(next)
+			continue
+		}
+
+		// the levels are applied right away when the spec changed, otherwise
+		// they are read first and only applied if they differ
+		specChanged := previous != nil && previous.UID == pod.UID && previous.Levels != desired
+		var levels string
+		var err error
+		if !specChanged {
+			levels, err = ovn_common.GetLogLevels(ctx, h.GetKClient(), config, &pods[i], container, appctl, modules)
+		}
+		if specChanged || err != nil || levels != desired {
+			levels, err = ovn_common.ApplyLogLevels(ctx, h.GetKClient(), config, &pods[i], container, appctl, level, modules)
+			if err != nil {
+				h.GetLogger().Info(fmt.Sprintf("Unable to set log levels of %s: %s", pod.Name, err))
+				continue
+			}
+		}
+		effective[pod.Name] = ovnv1.PodLogLevels{
+			Levels:    levels,
+			UID:       pod.UID,
+			CheckTime: metav1.NewTime(now),
+		}
+		requeueAfter(ovn_common.LogLevelsCheckInterval)
+	}
+	return effective, result
+}
+
+// reconcileVersions - return the versions of the components running in pods.
+// Versions which can't be queried yet are reported as unknown and retried on
+// the next reconcile.
+func reconcileVersions(
+	ctx context.Context,
+	h *helper.Helper,
+	config *rest.Config,
+	pods []corev1.Pod,
+	probes []ovn_common.VersionProbe,
+	current []ovnv1.ComponentVersion,
+) []ovnv1.ComponentVersion {
+	versions, err := ovn_common.ComponentVersions(ctx, h.GetKClient(), config, pods, probes, current)
+	if err != nil {
+		h.GetLogger().Info(err.Error())
+	}
+	return versions
+}
+
+// versionCompatibleCondition - returns the VersionCompatible condition for the
+// result of a version skew check
+func versionCompatibleCondition(skewErr error) *condition.Condition {
+	if skewErr != nil {
+		return condition.FalseCondition(
+			ovnv1.VersionCompatibleCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.VersionCompatibleErrorMessage,
+			skewErr.Error())
+	}
+	return condition.TrueCondition(ovnv1.VersionCompatibleCondition, ovnv1.VersionCompatibleReadyMessage)
+}
+
+// recordConditionEvents - emits an event for every condition whose status or
+// severity differs from the one saved at the beginning of the reconcile.
+// Conditions reset to Unknown are skipped as they only reflect the Init of
+// the condition list.
+func recordConditionEvents(
+	recorder record.EventRecorder,
+	obj runtime.Object,
+	saved condition.Conditions,
+	current condition.Conditions,
+) {
+	for _, c := range current {
+		if c.Status == corev1.ConditionUnknown {
+			continue
+		}
+		previous := saved.Get(c.Type)
+		if previous != nil && previous.Status == c.Status && previous.Severity == c.Severity {
+			continue
+		}
+
+		eventType := corev1.EventTypeNormal
+		if c.Status == corev1.ConditionFalse &&
+			(c.Severity == condition.SeverityWarning || c.Severity == condition.SeverityError) {
+			eventType = corev1.EventTypeWarning
+		}
+		recorder.Eventf(obj, eventType, fmt.Sprintf("%s%s", c.Type, c.Status),
+			"%s is %s: %s", c.Type, c.Status, c.Message)
+	}
+}
@@ -20,15 +20,19 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	netattdefv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,20 +48,24 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/daemonset"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
 	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
 	common_rbac "github.com/openstack-k8s-operators/lib-common/modules/common/rbac"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	ovn_metrics "github.com/openstack-k8s-operators/ovn-operator/pkg/metrics"
 	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncontroller"
 	appsv1 "k8s.io/api/apps/v1"
-	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 )
 
+// driftResyncInterval - interval of the reconciles which collect the drift of
+// the external-ids the config agents report in the annotations of the ovs pods
+const driftResyncInterval = time.Duration(60) * time.Second
+
 // getlog returns a logger object with a prefix of "conroller.name" and aditional controller context fields
 func (r *OVNControllerReconciler) GetLogger(ctx context.Context) logr.Logger {
 	return log.FromContext(ctx).WithName("Controllers").WithName("OVNController")
@@ -66,8 +74,10 @@ func (r *OVNControllerReconciler) GetLogger(ctx context.Context) logr.Logger {
 // OVNControllerReconciler reconciles a OVNController object
 type OVNControllerReconciler struct {
 	client.Client
-	Kclient kubernetes.Interface
-	Scheme  *runtime.Scheme
+	Kclient    kubernetes.Interface
+	Scheme     *runtime.Scheme
+	RestConfig *rest.Config
+	Recorder   record.EventRecorder
 }
 
 // GetClient -
@@ -81,10 +91,12 @@ func (r *OVNControllerReconciler) GetClient() client.Client {
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;
+//+kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create;
 //+kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=create;delete;get;list;patch;update;watch
-//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;patch;update;delete;
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=list;delete;
 //+kubebuilder:rbac:groups=ovn.openstack.org,resources=ovndbclusters,verbs=get;list;watch;
 //+kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;
 
 // service account, role, rolebinding
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
@@ -148,6 +160,15 @@ func (r *OVNControllerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 				instance.Status.Conditions.Mirror(condition.ReadyCondition))
 		}
 		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+		recordConditionEvents(r.Recorder, instance, savedConditions, instance.Status.Conditions)
+
+		name := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+		if instance.DeletionTimestamp.IsZero() {
+			ovn_metrics.ObserveReconcile("OVNController", name, instance.Status.Conditions, _err)
+		} else {
+			ovn_metrics.Forget("OVNController", name)
+		}
+
 		err := helper.PatchInstance(ctx, instance)
 		if err != nil {
 			_err = err
@@ -170,6 +191,8 @@ func (r *OVNControllerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		condition.UnknownCondition(condition.RoleReadyCondition, condition.InitReason, condition.RoleReadyInitMessage),
 		condition.UnknownCondition(condition.RoleBindingReadyCondition, condition.InitReason, condition.RoleBindingReadyInitMessage),
 		condition.UnknownCondition(condition.TLSInputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(ovnv1.VersionCompatibleCondition, condition.InitReason, ovnv1.VersionCompatibleInitMessage),
+		dbReadyInitCondition(ovnv1.SBDBType),
 	)
 
 	instance.Status.Conditions.Init(&cl)
@@ -217,10 +240,11 @@ func (r *OVNControllerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}); err != nil {
 		return err
 	}
+	ovn_metrics.Register()
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ovnv1.OVNController{}).
 		Owns(&corev1.ConfigMap{}).
-		Owns(&batchv1.Job{}).
 		Owns(&netattdefv1.NetworkAttachmentDefinition{}).
 		Owns(&appsv1.DaemonSet{}).
 		Owns(&corev1.ServiceAccount{}).
@@ -288,9 +312,6 @@ func (r *OVNControllerReconciler) reconcileInit(
 
 	Log.Info("Reconciling Service init")
 
-	// TODO(slaweq):
-	// * read status of the external IDs
-	// * if external IDs are different than required once, change them
 	Log.Info("Reconciled Service init successfully")
 	return ctrl.Result{}, nil
 }
@@ -420,6 +441,20 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 		return ctrl.Result{}, err
 	}
 
+	for key, override := range map[string]*runtime.RawExtension{
+		ovn_common.OverrideHashKey:          instance.Spec.Override.DaemonSet,
+		ovn_common.OverrideHashKey + "-ovs": instance.Spec.Override.OVSDaemonSet,
+	} {
+		if override == nil {
+			continue
+		}
+		hash, err := util.ObjectHash(override)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		configMapVars[key] = env.SetValue(hash)
+	}
+
 	//
 	// create hash over all the different input resources to identify if any those changed
 	// and a restart/recreate is required.
@@ -446,60 +481,11 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 	//
 
 	ovnServiceLabels := map[string]string{
-		common.AppSelector: ovnv1.ServiceNameOVNController,
+		common.AppSelector: instance.ScopedName(ovnv1.ServiceNameOVNController),
 	}
 
 	ovsServiceLabels := map[string]string{
-		common.AppSelector: ovnv1.ServiceNameOVS,
-	}
-
-	// Create or Update additional Physical Network Attachments
-	networkAttachments, err := ovncontroller.CreateOrUpdateAdditionalNetworks(ctx, helper, instance, ovsServiceLabels)
-	if err != nil {
-		Log.Info(fmt.Sprintf("Failed to create additional networks: %s", err))
-		return ctrl.Result{}, err
-	}
-
-	// network to attach to
-	networkAttachmentsNoPhysNet := []string{}
-	if instance.Spec.NetworkAttachment != "" {
-		networkAttachments = append(networkAttachments, instance.Spec.NetworkAttachment)
-		networkAttachmentsNoPhysNet = append(networkAttachmentsNoPhysNet, instance.Spec.NetworkAttachment)
-	}
-	sort.Strings(networkAttachments)
-
-	nadList := []netattdefv1.NetworkAttachmentDefinition{}
-	for _, netAtt := range networkAttachments {
-		nad, err := nad.GetNADWithName(ctx, helper, netAtt, instance.Namespace)
-		if err != nil {
-			if k8s_errors.IsNotFound(err) {
-				Log.Info(fmt.Sprintf("network-attachment-definition %s not found", netAtt))
-				instance.Status.Conditions.Set(condition.FalseCondition(
-					condition.NetworkAttachmentsReadyCondition,
-					condition.RequestedReason,
-					condition.SeverityInfo,
-					condition.NetworkAttachmentsReadyWaitingMessage,
-					netAtt))
-				return ctrl.Result{RequeueAfter: time.Second * 10}, nil
-			}
-			instance.Status.Conditions.Set(condition.FalseCondition(
-				condition.NetworkAttachmentsReadyCondition,
-				condition.ErrorReason,
-				condition.SeverityWarning,
-				condition.NetworkAttachmentsReadyErrorMessage,
-				err.Error()))
-			return ctrl.Result{}, err
-		}
-
-		if nad != nil {
-			nadList = append(nadList, *nad)
-		}
-	}
-
-	serviceAnnotations, err := nad.EnsureNetworksAnnotation(nadList)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to create network annotation from %s: %w",
-			networkAttachments, err)
+		common.AppSelector: instance.ScopedName(ovnv1.ServiceNameOVS),
 	}
 
 	// Handle service init
@@ -527,12 +513,8 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 	}
 
 	// Define a new DaemonSet object for OVNController
-	dset := daemonset.NewDaemonSet(
-		ovncontroller.CreateOVNDaemonSet(instance, inputHash, ovnServiceLabels),
-		time.Duration(5)*time.Second,
-	)
-
-	ctrlResult, err = dset.CreateOrPatch(ctx, helper)
+	dsDef := ovncontroller.CreateOVNDaemonSet(instance, inputHash, ovnServiceLabels)
+	err = ovn_common.ApplyOverride(dsDef, instance.Spec.Override.DaemonSet)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.DeploymentReadyCondition,
@@ -540,26 +522,11 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 			condition.SeverityWarning,
 			condition.DeploymentReadyErrorMessage,
 			err.Error()))
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.DeploymentReadyCondition,
-			condition.RequestedReason,
-			condition.SeverityInfo,
-			condition.DeploymentReadyRunningMessage))
-		return ctrlResult, nil
+		return ctrl.Result{}, err
 	}
+	dset := daemonset.NewDaemonSet(dsDef, time.Duration(5)*time.Second)
 
-	instance.Status.DesiredNumberScheduled = dset.GetDaemonSet().Status.DesiredNumberScheduled
-	instance.Status.NumberReady = dset.GetDaemonSet().Status.NumberReady
-
-	// Define a new DaemonSet object for OVS (ovsdb-server + ovs-vswitchd)
-	ovsdset := daemonset.NewDaemonSet(
-		ovncontroller.CreateOVSDaemonSet(instance, inputHash, ovsServiceLabels, serviceAnnotations),
-		time.Duration(5)*time.Second,
-	)
-
-	ctrlResult, err = ovsdset.CreateOrPatch(ctx, helper)
+	ctrlResult, err = dset.CreateOrPatch(ctx, helper)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.DeploymentReadyCondition,
@@ -577,7 +544,32 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 		return ctrlResult, nil
 	}
 
-	instance.Status.OVSNumberReady = ovsdset.GetDaemonSet().Status.NumberReady
+	instance.Status.DesiredNumberScheduled = dset.GetDaemonSet().Status.DesiredNumberScheduled
+	instance.Status.NumberReady = dset.GetDaemonSet().Status.NumberReady
+
+	// Define a DaemonSet object for OVS (ovsdb-server + ovs-vswitchd) per node group
+	nodeGroups := ovncontroller.GetNodeGroups(instance)
+	ovsDaemonSets := map[string]*appsv1.DaemonSet{}
+	instance.Status.OVSNumberReady = 0
+	for _, group := range nodeGroups {
+		ovsDs, ctrlResult, err := r.reconcileOVSDaemonSet(ctx, helper, instance, group, inputHash, ovsServiceLabels)
+		if err != nil {
+			return ctrlResult, err
+		} else if (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, nil
+		}
+		ovsDaemonSets[group.Name] = ovsDs
+		instance.Status.OVSNumberReady += ovsDs.Status.NumberReady
+	}
+	if err := r.deleteStaleOVSDaemonSets(ctx, helper, instance, nodeGroups); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// network to attach to
+	networkAttachmentsNoPhysNet := []string{}
+	if instance.Spec.NetworkAttachment != "" {
+		networkAttachmentsNoPhysNet = append(networkAttachmentsNoPhysNet, instance.Spec.NetworkAttachment)
+	}
 
 	// verify if network attachment matches expectations
 	networkReady, networkAttachmentStatus, err := nad.VerifyNetworkStatusFromAnnotation(ctx, helper, networkAttachmentsNoPhysNet, ovsServiceLabels, instance.Status.OVSNumberReady)
@@ -604,69 +596,376 @@ func (r *OVNControllerReconciler) reconcileNormal(ctx context.Context, instance
 	}
 	// create DaemonSet - end
 
-	sbCluster, err := ovnv1.GetDBClusterByType(ctx, helper, instance.Namespace, map[string]string{}, ovnv1.SBDBType)
+	// ovn-controller is upgraded first, the OVN_Southbound database must not
+	// run a newer release than any ovn-controller
+	podList, err := helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s in (%s,%s)", common.AppSelector,
+			instance.ScopedName(ovnv1.ServiceNameOVNController), instance.ScopedName(ovnv1.ServiceNameOVS)),
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	instance.Status.Versions = reconcileVersions(
+		ctx, helper, r.RestConfig, podList.Items, ovncontroller.VersionProbes, instance.Status.Versions)
+	sbCluster, sbEndpoint, err := getDBClusterEndpoint(ctx, helper, &instance.Status.Conditions, instance.Namespace, ovnv1.SBDBType)
 	if err != nil {
-		Log.Info("No SB OVNDBCluster defined. Exiting reconcile.")
+		return ctrl.Result{}, err
+	}
+	if sbCluster != nil {
+		instance.Status.Conditions.Set(versionCompatibleCondition(ovn_common.CheckVersionSkew(
+			sbCluster.Status.Versions, ovn_common.ComponentOVN, instance.Status.Versions, ovn_common.ComponentOVNController)))
+	} else {
+		instance.Status.Conditions.MarkTrue(ovnv1.VersionCompatibleCondition, ovnv1.VersionCompatibleReadyMessage)
+	}
+
+	// the config agents of the ovs pods apply the rendered node configuration
+	// of their group and report the hash of the applied configuration
+	configHashes := map[string]string{}
+	if sbEndpoint != "" {
+		configHashes, err = r.reconcileNodeConfig(ctx, helper, instance, nodeGroups, sbEndpoint)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.ServiceConfigReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.ServiceConfigReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+	configErrors := map[string]string{}
+	for _, node := range instance.Status.Nodes {
+		configErrors[node.Node] = node.ConfigError
+	}
+	instance.Status.Nodes = ovncontroller.NodeStatuses(instance, configHashes, podList.Items)
+	for _, node := range instance.Status.Nodes {
+		if node.ConfigError != "" && node.ConfigError != configErrors[node.Node] {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "NodeConfigFailed",
+				"Failed to apply the node configuration on %s: %s", node.Node, node.ConfigError)
+		}
+	}
+	instance.Status.NodeGroups = nil
+	if len(instance.Spec.NodeGroups) > 0 {
+		instance.Status.NodeGroups = nodeGroupStatus(instance, nodeGroups, ovsDaemonSets, configHashes, podList.Items)
+	}
+
+	// the old per node config jobs were replaced by the config agents
+	if err := r.deleteLegacyConfigJobs(ctx, helper, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+	for key := range instance.Status.Hash {
+		if strings.HasPrefix(key, ovnv1.OVNConfigHash+"-") {
+			delete(instance.Status.Hash, key)
+		}
+	}
+
+	if sbEndpoint == "" {
+		// the OVNDBCluster watch triggers the next reconcile
+		Log.Info("Waiting for the SB OVNDBCluster endpoint")
 		return ctrl.Result{}, nil
 	}
 
-	// create OVN Config Job - start
-	// Waits for OVS pods to run the configJob which basically will set config into OVS database
-	if instance.Status.OVSNumberReady != instance.Status.DesiredNumberScheduled {
-		Log.Info("OVS DaemonSet not ready yet. Configuration job cannot be started.")
-		return ctrl.Result{Requeue: true}, nil
+	ovsPods := r.observeNodeConfig(instance, configHashes, podList.Items)
+	if instance.Status.OVSNumberReady != instance.Status.DesiredNumberScheduled ||
+		instance.Status.ConfigNumberApplied != ovsPods {
+		Log.Info(fmt.Sprintf("Node configuration applied on %d of %d nodes", instance.Status.ConfigNumberApplied, ovsPods))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			ovnv1.NodeConfigApplyingMessage,
+			instance.Status.ConfigNumberApplied,
+			ovsPods))
+		// the agents annotate their pods, which are not watched
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
 	}
-	jobsDef, err := ovncontroller.ConfigJob(ctx, r.Client, instance, sbCluster, ovnServiceLabels)
+	instance.Status.SBEndpoint = sbEndpoint
+	instance.Status.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
+
+	Log.Info("Reconciled Service successfully")
+
+	// the agents report drifted external-ids in the annotations of their pods
+	return ctrl.Result{RequeueAfter: driftResyncInterval}, nil
+}
+
+// reconcileOVSDaemonSet - create or update the NetworkAttachmentDefinitions and
+// the ovs DaemonSet of a node group
+func (r *OVNControllerReconciler) reconcileOVSDaemonSet(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNController,
+	group ovncontroller.NodeGroup,
+	inputHash string,
+	serviceLabels map[string]string,
+) (*appsv1.DaemonSet, ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	// Create or Update additional Physical Network Attachments
+	networkAttachments, err := ovncontroller.CreateOrUpdateAdditionalNetworks(ctx, h, instance, group, serviceLabels)
 	if err != nil {
-		Log.Error(err, "Failed to create OVN controller configuration Job")
-		return ctrl.Result{}, err
+		Log.Info(fmt.Sprintf("Failed to create additional networks: %s", err))
+		return nil, ctrl.Result{}, err
 	}
-	for _, jobDef := range jobsDef {
-		configHashKey := ovnv1.OVNConfigHash + "-" + jobDef.Spec.Template.Spec.NodeName
-		configHash := instance.Status.Hash[configHashKey]
-		configJob := job.NewJob(
-			jobDef,
-			configHashKey,
-			false,
-			time.Duration(5)*time.Second,
-			configHash,
-		)
-		ctrlResult, err = configJob.DoJob(ctx, helper)
-		if (ctrlResult != ctrl.Result{}) {
-			instance.Status.Conditions.Set(
-				condition.FalseCondition(
-					condition.ServiceConfigReadyCondition,
+
+	// network to attach to
+	if instance.Spec.NetworkAttachment != "" {
+		networkAttachments = append(networkAttachments, instance.Spec.NetworkAttachment)
+	}
+	sort.Strings(networkAttachments)
+
+	nadList := []netattdefv1.NetworkAttachmentDefinition{}
+	for _, netAtt := range networkAttachments {
+		nad, err := nad.GetNADWithName(ctx, h, netAtt, instance.Namespace)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				Log.Info(fmt.Sprintf("network-attachment-definition %s not found", netAtt))
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.NetworkAttachmentsReadyCondition,
 					condition.RequestedReason,
 					condition.SeverityInfo,
-					condition.ServiceConfigReadyMessage,
-				),
-			)
-			return ctrlResult, nil
+					condition.NetworkAttachmentsReadyWaitingMessage,
+					netAtt))
+				return nil, ctrl.Result{RequeueAfter: time.Second * 10}, nil
+			}
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.NetworkAttachmentsReadyErrorMessage,
+				err.Error()))
+			return nil, ctrl.Result{}, err
+		}
+
+		if nad != nil {
+			nadList = append(nadList, *nad)
 		}
+	}
+
+	serviceAnnotations, err := nad.EnsureNetworksAnnotation(nadList)
+	if err != nil {
+		return nil, ctrl.Result{}, fmt.Errorf("failed to create network annotation from %s: %w",
+			networkAttachments, err)
+	}
+	if err := ovncontroller.SetPhysnetInterfaceNames(serviceAnnotations, group); err != nil {
+		return nil, ctrl.Result{}, err
+	}
+
+	ovsDsDef := ovncontroller.CreateOVSDaemonSet(instance, group, inputHash, group.Labels(serviceLabels), serviceAnnotations)
+	err = ovn_common.ApplyOverride(ovsDsDef, instance.Spec.Override.OVSDaemonSet)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return nil, ctrl.Result{}, err
+	}
+	ovsdset := daemonset.NewDaemonSet(ovsDsDef, time.Duration(5)*time.Second)
+
+	ctrlResult, err := ovsdset.CreateOrPatch(ctx, h)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return nil, ctrlResult, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.DeploymentReadyRunningMessage))
+		return nil, ctrlResult, nil
+	}
+
+	ovsDs := ovsdset.GetDaemonSet()
+	return &ovsDs, ctrl.Result{}, nil
+}
+
+// deleteStaleOVSDaemonSets - delete the ovs DaemonSets of removed node groups
+func (r *OVNControllerReconciler) deleteStaleOVSDaemonSets(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNController,
+	nodeGroups []ovncontroller.NodeGroup,
+) error {
+	Log := r.GetLogger(ctx)
+
+	dsList := &appsv1.DaemonSetList{}
+	err := h.GetClient().List(ctx, dsList,
+		client.InNamespace(instance.Namespace),
+		client.HasLabels{ovncontroller.NodeGroupLabel},
+	)
+	if err != nil {
+		return err
+	}
+
+	desired := map[string]bool{}
+	for _, group := range nodeGroups {
+		desired[group.OVSDaemonSetName()] = true
+	}
+	for i := range dsList.Items {
+		ds := &dsList.Items[i]
+		if desired[ds.Name] || !metav1.IsControlledBy(ds, instance) {
+			continue
+		}
+		Log.Info(fmt.Sprintf("Deleting DaemonSet %s of removed node group %s", ds.Name, ds.Labels[ovncontroller.NodeGroupLabel]))
+		if err := h.GetClient().Delete(ctx, ds); err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodeGroupStatus - count the nodes and ready pods of each node group
+func nodeGroupStatus(
+	instance *ovnv1.OVNController,
+	nodeGroups []ovncontroller.NodeGroup,
+	ovsDaemonSets map[string]*appsv1.DaemonSet,
+	configHashes map[string]string,
+	pods []corev1.Pod,
+) []ovnv1.OVNControllerNodeGroupStatus {
+	groupByNode := ovncontroller.NodeGroupsByNode(instance, pods)
+	ovnReady := map[string]int32{}
+	configApplied := map[string]int32{}
+	for _, pod := range pods {
+		group, ok := groupByNode[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		switch pod.Labels[common.AppSelector] {
+		case instance.ScopedName(ovnv1.ServiceNameOVNController):
+			if isPodReady(pod) {
+				ovnReady[group]++
+			}
+		case instance.ScopedName(ovnv1.ServiceNameOVS):
+			if ovncontroller.NodeConfigApplied(pod, configHashes[group]) {
+				configApplied[group]++
+			}
+		}
+	}
+
+	status := []ovnv1.OVNControllerNodeGroupStatus{}
+	for _, group := range nodeGroups {
+		groupStatus := ovnv1.OVNControllerNodeGroupStatus{
+			Name:                group.Name,
+			NumberReady:         ovnReady[group.Name],
+			ConfigNumberApplied: configApplied[group.Name],
+		}
+		if ds, ok := ovsDaemonSets[group.Name]; ok {
+			groupStatus.DesiredNumberScheduled = ds.Status.DesiredNumberScheduled
+			groupStatus.OVSNumberReady = ds.Status.NumberReady
+		}
+		status = append(status, groupStatus)
+	}
+	return status
+}
+
+// reconcileNodeConfig - render the node configuration of each group into the
+// ConfigMap mounted by the config agents, returns the hash of each group
+func (r *OVNControllerReconciler) reconcileNodeConfig(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNController,
+	nodeGroups []ovncontroller.NodeGroup,
+	sbEndpoint string,
+) (map[string]string, error) {
+	configHashes := map[string]string{}
+	data := map[string]string{}
+	for _, group := range nodeGroups {
+		config, hash, err := ovncontroller.NodeConfig(instance, group, sbEndpoint)
 		if err != nil {
-			Log.Error(err, "Failed to configure OVN controller")
-			instance.Status.Conditions.Set(
-				condition.FalseCondition(
-					condition.ServiceConfigReadyCondition,
-					condition.RequestedReason,
-					condition.SeverityInfo,
-					condition.ServiceConfigReadyErrorMessage,
-					err.Error(),
-				),
-			)
-			return ctrl.Result{}, err
+			return nil, err
 		}
-		if configJob.HasChanged() {
-			instance.Status.Hash[configHashKey] = configJob.GetHash()
-			Log.Info(fmt.Sprintf("Job %s hash added - %s", jobDef.Name, instance.Status.Hash[configHashKey]))
+		data[group.NodeConfigKey()] = config
+		configHashes[group.Name] = hash
+	}
+
+	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(ovnv1.ServiceNameOVNController), map[string]string{})
+	cms := []util.Template{
+		{
+			Name:         ovncontroller.NodeConfigMapName(instance),
+			Namespace:    instance.Namespace,
+			Type:         util.TemplateTypeNone,
+			InstanceType: instance.Kind,
+			Labels:       cmLabels,
+			CustomData:   data,
+		},
+	}
+	if err := configmap.EnsureConfigMaps(ctx, h, instance, cms, nil); err != nil {
+		return nil, err
+	}
+	return configHashes, nil
+}
+
+// deleteLegacyConfigJobs - delete the per node config jobs created by
+// earlier releases, which are not cleaned up if they never finished
+func (r *OVNControllerReconciler) deleteLegacyConfigJobs(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNController,
+) error {
+	Log := r.GetLogger(ctx)
+
+	jobs, err := h.GetKClient().BatchV1().Jobs(instance.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", common.AppSelector, instance.ScopedName(ovnv1.ServiceNameOVNController)),
+	})
+	if err != nil {
+		return err
+	}
+	propagation := metav1.DeletePropagationBackground
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if !metav1.IsControlledBy(job, instance) {
+			continue
+		}
+		err := h.GetKClient().BatchV1().Jobs(instance.Namespace).Delete(
+			ctx, job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
 		}
+		Log.Info(fmt.Sprintf("Deleted legacy config Job %s", job.Name))
 	}
-	instance.Status.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
-	// create OVN Config Job - end
+	return nil
+}
 
-	Log.Info("Reconciled Service successfully")
+// observeNodeConfig - count the ovs pods whose config agent applied the
+// current configuration of their group, record the state of each node in the
+// metrics and return the number of ovs pods
+func (r *OVNControllerReconciler) observeNodeConfig(
+	instance *ovnv1.OVNController,
+	configHashes map[string]string,
+	pods []corev1.Pod,
+) int32 {
+	instanceName := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	groupByNode := ovncontroller.NodeGroupsByNode(instance, pods)
+	drifted := map[string]int{}
+	for _, node := range instance.Status.Nodes {
+		drifted[node.Node] = len(node.ExternalIDsDrift)
+	}
 
-	return ctrl.Result{}, nil
+	var ovsPods int32
+	nodes := []string{}
+	instance.Status.ConfigNumberApplied = 0
+	for _, pod := range pods {
+		group, ok := groupByNode[pod.Spec.NodeName]
+		if !ok || pod.Labels[common.AppSelector] != instance.ScopedName(ovnv1.ServiceNameOVS) {
+			continue
+		}
+		ovsPods++
+		applied := ovncontroller.NodeConfigApplied(pod, configHashes[group])
+		if applied {
+			instance.Status.ConfigNumberApplied++
+		}
+		nodes = append(nodes, pod.Spec.NodeName)
+		ovn_metrics.ObserveNodeConfig("OVNController", instanceName, pod.Spec.NodeName, applied, drifted[pod.Spec.NodeName])
+	}
+	ovn_metrics.ForgetNodes("OVNController", instanceName, nodes)
+
+	return ovsPods
 }
 
 // generateServiceConfigMaps - create configmaps which hold scripts and service configuration
@@ -18,7 +18,9 @@ package controllers
 
 import (
 	"context"
+	cryptotls "crypto/tls"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -28,7 +30,10 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -54,9 +59,12 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	ovn_metrics "github.com/openstack-k8s-operators/ovn-operator/pkg/metrics"
 	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovndbcluster"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovsdb"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 )
@@ -64,8 +72,10 @@ import (
 // OVNDBClusterReconciler reconciles a OVNDBCluster object
 type OVNDBClusterReconciler struct {
 	client.Client
-	Kclient kubernetes.Interface
-	Scheme  *runtime.Scheme
+	Kclient    kubernetes.Interface
+	Scheme     *runtime.Scheme
+	RestConfig *rest.Config
+	Recorder   record.EventRecorder
 }
 
 // GetClient -
@@ -95,8 +105,11 @@ func (r *OVNDBClusterReconciler) GetLogger(ctx context.Context) logr.Logger {
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;patch;update;delete;
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;patch;update;delete;
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;patch;update;delete;
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;
+//+kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create;
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;
 //+kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
 //+kubebuilder:rbac:groups=network.openstack.org,resources=dnsdata,verbs=get;list;watch;create;update;patch;delete
 
@@ -173,19 +186,32 @@ func (r *OVNDBClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	// Always patch the instance status when exiting this function so we can persist any changes.
 	defer func() {
-		// update the Ready condition based on the sub conditions
-		if instance.Status.Conditions.AllSubConditionIsTrue() {
+		// update the Ready condition based on the sub conditions, the result
+		// of the database integrity check is informational only
+		readyConditions := instance.Status.Conditions.DeepCopy()
+		readyConditions.Remove(ovnv1.DatabaseIntegrityCondition)
+		if readyConditions.AllSubConditionIsTrue() {
 			instance.Status.Conditions.MarkTrue(
 				condition.ReadyCondition, condition.ReadyMessage)
 		} else {
 			// something is not ready so reset the Ready condition
-			instance.Status.Conditions.MarkUnknown(
+			readyConditions.MarkUnknown(
 				condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage)
 			// and recalculate it based on the state of the rest of the conditions
 			instance.Status.Conditions.Set(
-				instance.Status.Conditions.Mirror(condition.ReadyCondition))
+				readyConditions.Mirror(condition.ReadyCondition))
 		}
 		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+		recordConditionEvents(r.Recorder, instance, savedConditions, instance.Status.Conditions)
+
+		name := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+		if instance.DeletionTimestamp.IsZero() {
+			ovn_metrics.ObserveReconcile("OVNDBCluster", name, instance.Status.Conditions, _err)
+			ovn_metrics.ObserveDBMembers("OVNDBCluster", name, instance.Spec.GetReplicas(), instance.Status.ReadyCount)
+		} else {
+			ovn_metrics.Forget("OVNDBCluster", name)
+		}
+
 		err := helper.PatchInstance(ctx, instance)
 		if err != nil {
 			_err = err
@@ -234,11 +260,14 @@ func (r *OVNDBClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	ovn_metrics.Register()
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ovnv1.OVNDBCluster{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
@@ -477,7 +506,25 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 	// create Configmap required for dbcluster input
 	// - %-config configmap holding minimal dbcluster config required to get the service up
 	//
+	// the extra args are rendered into the scripts, never render args which
+	// did not pass the webhook validation, e.g. with the webhooks disabled
+	if errs := instance.Spec.ExtraArgs.Validate(field.NewPath("spec", "extraArgs")); len(errs) > 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			errs.ToAggregate().Error()))
+		// the next spec change triggers a reconcile
+		return ctrl.Result{}, nil
+	}
+
+	initActiveMember(instance, serviceName)
 	err = r.generateServiceConfigMaps(ctx, helper, instance, &configMapVars, serviceName)
+	if err == nil {
+		// - %-runtime configmap holding settings read on pod start which are not part of the hash
+		err = r.ensureRuntimeConfigMap(ctx, helper, instance, serviceName)
+	}
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.ServiceConfigReadyCondition,
@@ -488,6 +535,14 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 		return ctrl.Result{}, err
 	}
 
+	if instance.Spec.Override.StatefulSet != nil {
+		hash, err := util.ObjectHash(instance.Spec.Override.StatefulSet)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		configMapVars[ovn_common.OverrideHashKey] = env.SetValue(hash)
+	}
+
 	//
 	// create hash over all the different input resources to identify if any those changed
 	// and a restart/recreate is required.
@@ -525,11 +580,32 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 	} else if (ctrlResult != ctrl.Result{}) {
 		return ctrlResult, nil
 	}
+	// keep the quorum of the cluster during voluntary disruptions like node drains
+	err = ovn_common.EnsurePodDisruptionBudget(ctx, helper, serviceName,
+		ovndbcluster.PodDisruptionBudget(instance, serviceLabels))
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
 	// Define a new Statefulset object
-	sfset := statefulset.NewStatefulSet(
-		ovndbcluster.StatefulSet(instance, inputHash, serviceLabels, serviceAnnotations),
-		time.Duration(5)*time.Second,
-	)
+	ssDef := ovndbcluster.StatefulSet(instance, inputHash, serviceLabels, serviceAnnotations)
+	err = ovn_common.ApplyOverride(ssDef, instance.Spec.Override.StatefulSet)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	sfset := statefulset.NewStatefulSet(ssDef, time.Duration(5)*time.Second)
 
 	ctrlResult, err = sfset.CreateOrPatch(ctx, helper)
 	if err != nil {
@@ -549,7 +625,14 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 		return ctrlResult, nil
 	}
 
-	instance.Status.ReadyCount = sfset.GetStatefulSet().Status.ReadyReplicas
+	sts := sfset.GetStatefulSet()
+	instance.Status.ReadyCount = sts.Status.ReadyReplicas
+	if completeModeChange(instance, &sts) {
+		err = r.ensureRuntimeConfigMap(ctx, helper, instance, serviceName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 
 	// verify if network attachment matches expectations
 	networkReady, networkAttachmentStatus, err := nad.VerifyNetworkStatusFromAnnotation(ctx, helper, networkAttachments, serviceLabels, instance.Status.ReadyCount)
@@ -573,6 +656,21 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 	}
 
 	// create Statefulset - end
+
+	// select the member serving the database in standalone and active-backup mode
+	// the result is only returned at the end, waiting for a failover must not
+	// block the rest of the reconcile
+	activeMemberResult, err := r.reconcileActiveMember(ctx, instance, helper, serviceLabels, serviceName)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return activeMemberResult, err
+	}
+
 	// Handle service init
 	ctrlResult, err = r.reconcileServices(ctx, instance, helper, serviceLabels, serviceName)
 	if err != nil {
@@ -624,6 +722,11 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 			if svc.Spec.ClusterIP == "None" || svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
 				continue
 			}
+			// In active-backup mode clients must only connect to the active member
+			isActiveSvc := svc.Labels["type"] == ovnv1.ServiceActiveType
+			if isActiveSvc != (instance.Spec.Mode == ovnv1.DBModeActiveBackup) {
+				continue
+			}
 			// TODO: Watch operator.openshift.io resource once cluster domain is customizable
 			clusterDomain := clusterdns.GetDNSClusterDomain()
 			internalDbAddress = append(internalDbAddress, fmt.Sprintf("%s:%s.%s.svc.%s:%d", scheme, svc.Name, svc.Namespace, clusterDomain, svcPort))
@@ -647,10 +750,451 @@ func (r *OVNDBClusterReconciler) reconcileNormal(ctx context.Context, instance *
 		}
 
 	}
+
+	podList, err := ovndbcluster.OVNDBPods(ctx, instance, helper, serviceLabels)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ctrlResult = r.reconcileIntegrityCheck(ctx, instance, podList.Items)
+
+	// apply log level changes to the running members without a restart
+	var logLevelsResult ctrl.Result
+	instance.Status.LogLevels, logLevelsResult = reconcileLogLevels(
+		ctx, helper, r.RestConfig, podList.Items, serviceName, ovndbcluster.AppctlCommand(instance.Spec.DBType),
+		instance.Spec.LogLevel, instance.Spec.LogModules, instance.Status.LogLevels)
+
+	instance.Status.Versions = reconcileVersions(
+		ctx, helper, r.RestConfig, podList.Items, ovndbcluster.VersionProbes(serviceName, instance.Spec.DBType),
+		instance.Status.Versions)
+
+	configResult := r.reconcileDatabaseConfig(ctx, instance, podList.Items)
+
+	for _, res := range []ctrl.Result{activeMemberResult, configResult, logLevelsResult} {
+		if res.RequeueAfter > 0 &&
+			(ctrlResult.RequeueAfter == 0 || res.RequeueAfter < ctrlResult.RequeueAfter) {
+			ctrlResult = res
+		}
+	}
+
 	Log.Info("Reconciled Service successfully")
+	return ctrlResult, nil
+}
+
+// reconcileIntegrityCheck - compare the results of the integrity checks
+// reported by the integrity-check containers of the cluster members. The
+// result is informational, it doesn't affect the Ready condition.
+func (r *OVNDBClusterReconciler) reconcileIntegrityCheck(
+	ctx context.Context,
+	instance *ovnv1.OVNDBCluster,
+	pods []corev1.Pod,
+) ctrl.Result {
+	Log := r.GetLogger(ctx)
+
+	if !instance.Spec.IntegrityCheck.Enabled {
+		instance.Status.IntegrityCheck = nil
+		return ctrl.Result{}
+	}
+
+	members := []ovnv1.OVNDBMemberIntegrity{}
+	var lastCheckTime metav1.Time
+	for _, pod := range pods {
+		member, ok := ovndbcluster.MemberIntegrity(pod)
+		if !ok {
+			continue
+		}
+		members = append(members, member)
+		if lastCheckTime.Before(&member.CheckTime) {
+			lastCheckTime = member.CheckTime
+		}
+	}
+
+	// the pods are not watched, the results are picked up periodically
+	interval := time.Duration(instance.Spec.IntegrityCheck.Interval) * time.Second
+	previous := instance.Status.IntegrityCheck
+	if len(members) == 0 {
+		return ctrl.Result{RequeueAfter: interval}
+	}
+
+	if previous == nil || ovndbcluster.NewIntegrityResults(members, previous.Members) {
+		problems := ovndbcluster.IntegrityProblems(members, previous)
+		instance.Status.IntegrityCheck = &ovnv1.OVNDBClusterIntegrityStatus{
+			LastCheckTime: lastCheckTime,
+			Members:       members,
+			Problems:      problems,
+		}
+
+		if len(problems) > 0 {
+			Log.Info(fmt.Sprintf("Database integrity check failed: %s", strings.Join(problems, "; ")))
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "DatabaseIntegrityCheckFailed",
+				ovnv1.DatabaseIntegrityErrorMessage, strings.Join(problems, "; "))
+		} else if previous != nil && len(previous.Problems) > 0 {
+			r.Recorder.Event(instance, corev1.EventTypeNormal, "DatabaseIntegrityCheckPassed",
+				ovnv1.DatabaseIntegrityReadyMessage)
+		}
+	}
+
+	// conditions get reset on every reconcile, restore it from the result of the last check
+	status := instance.Status.IntegrityCheck
+	if len(status.Problems) > 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.DatabaseIntegrityCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.DatabaseIntegrityErrorMessage,
+			strings.Join(status.Problems, "; ")))
+	} else {
+		instance.Status.Conditions.MarkTrue(ovnv1.DatabaseIntegrityCondition, ovnv1.DatabaseIntegrityReadyMessage)
+	}
+
+	return ctrl.Result{RequeueAfter: interval}
+}
+
+// reconcileDatabaseConfig - keep the settings of the remote connections and
+// the NB_Global or SB_Global options in sync with the spec. The database is
+// checked periodically and settings changed by someone else are set back.
+func (r *OVNDBClusterReconciler) reconcileDatabaseConfig(
+	ctx context.Context,
+	instance *ovnv1.OVNDBCluster,
+	pods []corev1.Pod,
+) ctrl.Result {
+	Log := r.GetLogger(ctx)
+	requeue := ctrl.Result{RequeueAfter: ovndbcluster.ConfigCheckInterval}
+
+	// the clients are pointed to the members which serve the database
+	ready := false
+	for _, pod := range pods {
+		if isPodReady(pod) && pod.DeletionTimestamp == nil {
+			ready = true
+			break
+		}
+	}
+	endpoint, err := instance.GetInternalEndpoint()
+	if !ready || err != nil {
+		return requeue
+	}
+
+	var tlsConfig *cryptotls.Config
+	if instance.Spec.TLS.Enabled() {
+		tlsConfig, err = ovsdb.TLSConfigFromSecrets(ctx, r.Client, instance.Namespace,
+			*instance.Spec.TLS.SecretName, instance.Spec.TLS.CaBundleSecretName)
+		if err != nil {
+			Log.Info(fmt.Sprintf("Unable to load the TLS configuration of the %s database: %s",
+				instance.Spec.DBType, err))
+			return requeue
+		}
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, ovndbcluster.ConfigTimeout)
+	defer cancel()
+	dbClient, err := ovsdb.NewClient(dbCtx, Log.WithName("ovsdb"), instance.Spec.DBType, endpoint, tlsConfig)
+	if err != nil {
+		Log.Info(err.Error())
+		return requeue
+	}
+	defer dbClient.Close()
+
+	if err := r.reconcileConnections(dbCtx, instance, dbClient); err != nil {
+		Log.Info(fmt.Sprintf("Unable to update the %s database connections: %s", instance.Spec.DBType, err))
+	}
+	if err := r.reconcileOptions(dbCtx, instance, dbClient); err != nil {
+		Log.Info(fmt.Sprintf("Unable to update the %s options: %s",
+			ovndbcluster.GlobalTable(instance.Spec.DBType), err))
+	}
+	return requeue
+}
+
+// reconcileConnections - apply the inactivity probe and the remote options to
+// the connections created by setup.sh
+func (r *OVNDBClusterReconciler) reconcileConnections(
+	ctx context.Context,
+	instance *ovnv1.OVNDBCluster,
+	dbClient *ovsdb.Client,
+) error {
+	Log := r.GetLogger(ctx)
+
+	conns, err := dbClient.Connections(ctx)
+	if err != nil {
+		return err
+	}
+	for _, conn := range conns {
+		desired := ovndbcluster.RemoteConnection(instance, conn)
+		if !ovndbcluster.ConnectionChanged(conn, desired) {
+			continue
+		}
+		if err := dbClient.EnsureConnection(ctx, desired); err != nil {
+			return err
+		}
+		Log.Info(fmt.Sprintf("Updated the settings of the %s database connection %s",
+			instance.Spec.DBType, conn.Target))
+	}
+	return nil
+}
+
+// reconcileOptions - keep the NB_Global or SB_Global options in sync with the
+// spec. Options removed from the spec are removed from the database, options
+// never managed by the operator are left untouched. The status reports the
+// values read back from the database.
+func (r *OVNDBClusterReconciler) reconcileOptions(
+	ctx context.Context,
+	instance *ovnv1.OVNDBCluster,
+	dbClient *ovsdb.Client,
+) error {
+	if len(instance.Spec.Options) == 0 && len(instance.Status.Options) == 0 {
+		return nil
+	}
+	table := ovndbcluster.GlobalTable(instance.Spec.DBType)
+
+	current, err := dbClient.GlobalOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	set, remove := ovndbcluster.OptionsChanges(current, instance.Spec.Options, instance.Status.Options)
+	if len(set) > 0 || len(remove) > 0 {
+		drifted := []string{}
+		for key, value := range set {
+			if applied, ok := instance.Status.Options[key]; ok && applied == value {
+				drifted = append(drifted, key)
+			}
+		}
+		if err := dbClient.SetGlobalOptions(ctx, set, remove); err != nil {
+			return err
+		}
+		current, err = dbClient.GlobalOptions(ctx)
+		if err != nil {
+			return err
+		}
+		if len(drifted) > 0 {
+			sort.Strings(drifted)
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "DatabaseOptionsDrift",
+				"%s options %s were changed outside of the operator and have been reset",
+				table, strings.Join(drifted, ", "))
+		} else {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "DatabaseOptionsUpdated",
+				"%s options updated", table)
+		}
+	}
+
+	instance.Status.Options = ovndbcluster.AppliedOptions(current, instance.Spec.Options)
+	return nil
+}
+
+// initActiveMember - select the member serving the database in standalone and
+// active-backup mode before the runtime ConfigMap gets rendered. In
+// active-backup mode it is changed by a failover only. When converting to
+// clustered mode it is kept until all members run in clustered mode, the
+// active member bootstraps the cluster from its data.
+func initActiveMember(instance *ovnv1.OVNDBCluster, serviceName string) {
+	firstMember := serviceName + "-0"
+	switch instance.Spec.Mode {
+	case ovnv1.DBModeStandalone:
+		instance.Status.ActiveMember = firstMember
+		instance.Status.Failover = nil
+	case ovnv1.DBModeActiveBackup:
+		if instance.Status.ActiveMember == "" {
+			instance.Status.ActiveMember = firstMember
+		}
+	default:
+		instance.Status.Failover = nil
+	}
+}
+
+// completeModeChange - forget the former active member once all members of
+// the StatefulSet were restarted in clustered mode
+func completeModeChange(instance *ovnv1.OVNDBCluster, sts *appsv1.StatefulSet) bool {
+	if instance.Spec.Mode != ovnv1.DBModeClustered || instance.Status.ActiveMember == "" {
+		return false
+	}
+	if sts.Status.ObservedGeneration < sts.Generation ||
+		sts.Status.UpdateRevision != sts.Status.CurrentRevision ||
+		sts.Status.UpdatedReplicas != instance.Spec.GetReplicas() ||
+		sts.Status.ReadyReplicas != instance.Spec.GetReplicas() {
+		return false
+	}
+	instance.Status.ActiveMember = ""
+	return true
+}
+
+// serviceMember - member selected by the service in standalone and
+// active-backup mode. During a failover the backup rejects writes until it
+// is promoted, the service keeps selecting the former active member.
+func serviceMember(instance *ovnv1.OVNDBCluster) string {
+	if instance.Spec.Mode == ovnv1.DBModeClustered {
+		return ""
+	}
+	if instance.Status.Failover != nil {
+		return instance.Status.Failover.From
+	}
+	return instance.Status.ActiveMember
+}
+
+// reconcileActiveMember - fail over to the backup if the active member is not
+// ready in active-backup mode
+func (r *OVNDBClusterReconciler) reconcileActiveMember(
+	ctx context.Context,
+	instance *ovnv1.OVNDBCluster,
+	helper *helper.Helper,
+	serviceLabels map[string]string,
+	serviceName string,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	if instance.Spec.Mode != ovnv1.DBModeActiveBackup {
+		return ctrl.Result{}, nil
+	}
+
+	podList, err := ovndbcluster.OVNDBPods(ctx, instance, helper, serviceLabels)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if instance.Status.Failover != nil {
+		return r.completeFailover(ctx, instance, podList.Items, serviceName)
+	}
+
+	var active, backup *corev1.Pod
+	for i, pod := range podList.Items {
+		if pod.Name == instance.Status.ActiveMember {
+			active = &podList.Items[i]
+		} else if isPodReady(pod) && backup == nil {
+			backup = &podList.Items[i]
+		}
+	}
+
+	// give a starting or restarting active member the time to become ready
+	// before failing over
+	failoverTimeout := time.Duration(instance.Spec.FailoverTimeout) * time.Second
+	if active != nil && !isPodReady(*active) && backup != nil {
+		notReadySince := active.CreationTimestamp.Time
+		for _, c := range active.Status.Conditions {
+			if c.Type == corev1.PodReady {
+				notReadySince = c.LastTransitionTime.Time
+			}
+		}
+		if wait := time.Until(notReadySince.Add(failoverTimeout)); wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	if (active == nil || !isPodReady(*active)) && backup != nil {
+		Log.Info(fmt.Sprintf("Active member %s is not ready, failing over to %s", instance.Status.ActiveMember, backup.Name))
+		instance.Status.Failover = &ovnv1.OVNDBClusterFailoverStatus{
+			From:      instance.Status.ActiveMember,
+			To:        backup.Name,
+			StartTime: metav1.Now(),
+		}
+		instance.Status.ActiveMember = backup.Name
+
+		// the former active member reads the new active member when it
+		// restarts and replicates from it
+		err = r.ensureRuntimeConfigMap(ctx, helper, instance, serviceName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// fence the former active member, it must not accept writes
+		// anymore once the backup is promoted
+		if active != nil {
+			instance.Status.Failover.FromUID = active.UID
+			err = helper.GetClient().Delete(ctx, active)
+			if err != nil && !k8s_errors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("error fencing former active member %s: %w", active.Name, err)
+			}
+		}
+		return ctrl.Result{RequeueAfter: ovndbcluster.FailoverPollInterval}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// completeFailover - promote the backup member once the former active member
+// is fenced. The pod of the former active member is only gone once the kubelet
+// confirmed its containers are stopped. If its node is unreachable the pod
+// stays terminating until the node is fenced or the pod is force deleted,
+// promoting the backup before could result in two active members.
+func (r *OVNDBClusterReconciler) completeFailover(
+	ctx context.Context,
+	instance *ovnv1.OVNDBCluster,
+	pods []corev1.Pod,
+	serviceName string,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+	failover := instance.Status.Failover
+
+	var target *corev1.Pod
+	for i, pod := range pods {
+		if failover.FromUID != "" && pod.UID == failover.FromUID {
+			Log.Info(fmt.Sprintf("Waiting for the former active member %s to be fenced", failover.From))
+			return ctrl.Result{RequeueAfter: ovndbcluster.FailoverPollInterval}, nil
+		}
+		if pod.Name == failover.To {
+			target = &pods[i]
+		}
+	}
+
+	if target == nil || !isPodReady(*target) {
+		// the backup member failed meanwhile, give up on it. The active
+		// member is selected again once a member is ready.
+		if time.Since(failover.StartTime.Time) > time.Duration(instance.Spec.FailoverTimeout)*time.Second {
+			Log.Info(fmt.Sprintf("Backup member %s did not become ready, aborting the failover", failover.To))
+			instance.Status.Failover = nil
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{RequeueAfter: ovndbcluster.FailoverPollInterval}, nil
+	}
+
+	_, err := ovn_common.ExecInPod(ctx, r.Kclient, r.RestConfig, target, serviceName,
+		[]string{ovndbcluster.PromoteCommand})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error promoting %s to active: %w", target.Name, err)
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeWarning, "ActiveBackupFailover",
+		"Promoted %s to active, %s is not ready", failover.To, failover.From)
+	instance.Status.Failover = nil
+
 	return ctrl.Result{}, nil
 }
 
+// ensureRuntimeConfigMap - create the ConfigMap holding the settings read by the
+// pods at runtime. It is not part of the input hash so changes don't trigger a rollout.
+func (r *OVNDBClusterReconciler) ensureRuntimeConfigMap(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNDBCluster,
+	serviceName string,
+) error {
+	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(serviceName), map[string]string{})
+
+	cms := []util.Template{
+		{
+			Name:         instance.Name + ovn_common.RuntimeConfigMapSuffix,
+			Namespace:    instance.Namespace,
+			Type:         util.TemplateTypeNone,
+			InstanceType: instance.Kind,
+			Labels:       cmLabels,
+			CustomData: map[string]string{
+				ovndbcluster.ActiveMemberKey: instance.Status.ActiveMember,
+				ovn_common.LogLevelKey:       instance.Spec.LogLevel,
+				ovn_common.LogModulesKey:     ovn_common.LogModulesValue(instance.Spec.LogModules),
+			},
+		},
+	}
+	return configmap.EnsureConfigMaps(ctx, h, instance, cms, nil)
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 func getPodIPInNetwork(ovnPod corev1.Pod, namespace string, networkAttachment string) (string, error) {
 	netStat, err := nad.GetNetworkStatusFromAnnotation(ovnPod.Annotations)
 	if err != nil {
@@ -683,6 +1227,16 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 	var ssvc *service.Service
 	var err error
 	svcOverride := instance.Spec.Override.Service
+
+	// In standalone and active-backup mode only the active member serves the
+	// database, failover flips the selector to the new active member once it
+	// is promoted.
+	activeSelectorLabels := serviceLabels
+	if member := serviceMember(instance); member != "" {
+		activeSelectorLabels = util.MergeMaps(serviceLabels, map[string]string{
+			"statefulset.kubernetes.io/pod-name": member,
+		})
+	}
 	if svcOverride != nil {
 		if svcOverride.EmbeddedLabelsAnnotations == nil {
 			svcOverride.EmbeddedLabelsAnnotations = &service.EmbeddedLabelsAnnotations{}
@@ -707,11 +1261,13 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 			if err != nil && !k8s_errors.IsNotFound(err) {
 				return ctrl.Result{}, fmt.Errorf("error deleting service for transition to use serviceOerrides %s: %w", serviceName, err)
 			}
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "ServiceRecreated",
+				"Service %s is recreated to switch from headless to %s", serviceName, svcOverride.Spec.Type)
 		}
 
 		svcLabels := util.MergeMaps(serviceLabels, map[string]string{"type": strings.ToLower(string(svcOverride.Spec.Type))})
 		ssvc, err = service.NewService(
-			ovndbcluster.Service(serviceName, instance, svcLabels, serviceLabels),
+			ovndbcluster.Service(serviceName, instance, svcLabels, activeSelectorLabels),
 			time.Duration(5)*time.Second,
 			svcOverride,
 		)
@@ -736,6 +1292,8 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 			if err != nil && !k8s_errors.IsNotFound(err) {
 				return ctrl.Result{}, fmt.Errorf("error deleting service for transition to use headless service %s: %w", serviceName, err)
 			}
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "ServiceRecreated",
+				"Service %s is recreated to switch from %s to headless", serviceName, svc.Spec.Type)
 		}
 
 		svcLabels := util.MergeMaps(serviceLabels, map[string]string{"type": ovnv1.ServiceHeadlessType})
@@ -787,6 +1345,37 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 		// create service - end
 	}
 
+	// Service pointing to the active member in active-backup mode
+	activeServiceName := serviceName + "-" + ovnv1.ServiceActiveType
+	if instance.Spec.Mode == ovnv1.DBModeActiveBackup {
+		activeServiceLabels := util.MergeMaps(serviceLabels, map[string]string{"type": ovnv1.ServiceActiveType})
+		asvc, err := service.NewService(
+			ovndbcluster.Service(activeServiceName, instance, activeServiceLabels, activeSelectorLabels),
+			time.Duration(5)*time.Second,
+			nil,
+		)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		ctrlResult, err := asvc.CreateOrPatch(ctx, helper)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if (ctrlResult != ctrl.Result{}) {
+			return ctrl.Result{}, nil
+		}
+	} else {
+		svc, err := service.GetServiceWithName(ctx, helper, activeServiceName, instance.Namespace)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		if svc != nil {
+			err = helper.GetClient().Delete(ctx, svc)
+			if err != nil && !k8s_errors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("error deleting service %s: %w", activeServiceName, err)
+			}
+		}
+	}
+
 	// Delete any extra services left after scale down
 	clusterServiceLabels := util.MergeMaps(serviceLabels, map[string]string{"type": ovnv1.ServiceClusterType})
 	svcList, err := service.GetServicesListWithLabel(
@@ -795,8 +1384,8 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 		helper.GetBeforeObject().GetNamespace(),
 		clusterServiceLabels,
 	)
-	if err == nil && len(svcList.Items) > int(*(instance.Spec.Replicas)) {
-		for i := len(svcList.Items) - 1; i >= int(*(instance.Spec.Replicas)); i-- {
+	if err == nil && len(svcList.Items) > int(instance.Spec.GetReplicas()) {
+		for i := len(svcList.Items) - 1; i >= int(instance.Spec.GetReplicas()); i-- {
 			fullServiceName := fmt.Sprintf("%s-%d", serviceName, i)
 			svcLabels := map[string]string{
 				common.AppSelector:                   serviceName,
@@ -812,6 +1401,8 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 				err = fmt.Errorf("error while deleting service with name %s: %w", fullServiceName, err)
 				return ctrl.Result{}, err
 			}
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "ServiceDeleted",
+				"Service %s is deleted after scale down to %d replicas", fullServiceName, instance.Spec.GetReplicas())
 		}
 	}
 
@@ -824,8 +1415,12 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 	if instance.Spec.NetworkAttachment != "" && ssvc.GetServiceType() != corev1.ServiceTypeLoadBalancer {
 		var dnsIPsList []string
 		// TODO(averdagu): use built in Min once go1.21 is used
-		minLen := ovn_common.Min(len(podList.Items), int(*(instance.Spec.Replicas)))
+		minLen := ovn_common.Min(len(podList.Items), int(instance.Spec.GetReplicas()))
 		for _, ovnPod := range podList.Items[:minLen] {
+			// only the active member gets resolved in standalone and active-backup mode
+			if member := serviceMember(instance); member != "" && ovnPod.Name != member {
+				continue
+			}
 			svc, err = service.GetServiceWithName(
 				ctx,
 				helper,
@@ -845,7 +1440,7 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 		// DNSData info is called every reconcile loop to ensure that even if a pod gets
 		// restarted and it's IP has changed, the DNSData CR will have the correct info.
 		// If nothing changed this won't modify the current dnsmasq pod.
-		err = ovndbcluster.DNSData(
+		op, err := ovndbcluster.DNSData(
 			ctx,
 			helper,
 			serviceName,
@@ -856,12 +1451,16 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+		if op != controllerutil.OperationResultNone {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "DNSDataUpdated",
+				"DNSData %s %s with addresses %s", serviceName, op, strings.Join(dnsIPsList, ","))
+		}
 		// It can be possible that not all pods are ready, so DNSData won't
 		// have complete information, return error to retrigger reconcile loop
 		// Returning here instead of at the beggining of the for is done to
 		// expose the already created pods to other services/dataplane nodes
-		if len(podList.Items) < int(*(instance.Spec.Replicas)) {
-			Log.Info(fmt.Sprintf("not all pods are yet created, number of expected pods: %v, current pods: %v", *(instance.Spec.Replicas), len(podList.Items)))
+		if len(podList.Items) < int(instance.Spec.GetReplicas()) {
+			Log.Info(fmt.Sprintf("not all pods are yet created, number of expected pods: %v, current pods: %v", instance.Spec.GetReplicas(), len(podList.Items)))
 			return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 		}
 	} else {
@@ -877,6 +1476,10 @@ func (r *OVNDBClusterReconciler) reconcileServices(
 		if err != nil && !k8s_errors.IsNotFound(err) {
 			return ctrl.Result{}, fmt.Errorf("error deleting dnsdata %s: %w", serviceName, err)
 		}
+		if err == nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "DNSDataDeleted",
+				"DNSData %s is deleted", serviceName)
+		}
 	}
 
 	// dbAddress will contain ovsdbserver-(nb|sb).openstack.svc or empty
@@ -969,7 +1572,7 @@ func (r *OVNDBClusterReconciler) generateServiceConfigMaps(
 
 	templateParameters := make(map[string]interface{})
 
-	templateParameters["OVN_LOG_LEVEL"] = instance.Spec.LogLevel
+	templateParameters["MODE"] = instance.Spec.Mode
 	templateParameters["SERVICE_NAME"] = serviceName
 	templateParameters["NAMESPACE"] = instance.GetNamespace()
 	templateParameters["DB_TYPE"] = strings.ToLower(instance.Spec.DBType)
@@ -979,13 +1582,19 @@ func (r *OVNDBClusterReconciler) generateServiceConfigMaps(
 		templateParameters["DB_PORT"] = ovndbcluster.DbPortSB
 		templateParameters["RAFT_PORT"] = ovndbcluster.RaftPortSB
 	}
-	templateParameters["OVN_ELECTION_TIMER"] = instance.Spec.ElectionTimer
-	templateParameters["OVN_INACTIVITY_PROBE"] = instance.Spec.InactivityProbe
-	templateParameters["OVN_PROBE_INTERVAL_TO_ACTIVE"] = instance.Spec.ProbeIntervalToActive
+	templateParameters["OVN_ELECTION_TIMER"] = instance.Spec.GetElectionTimer()
+	templateParameters["OVN_INACTIVITY_PROBE"] = instance.Spec.GetInactivityProbe()
+	templateParameters["OVN_PROBE_INTERVAL_TO_ACTIVE"] = instance.Spec.GetProbeIntervalToActive()
 	templateParameters["TLS"] = instance.Spec.TLS.Enabled()
 	templateParameters["OVNDB_CERT_PATH"] = ovn_common.OVNDbCertPath
 	templateParameters["OVNDB_KEY_PATH"] = ovn_common.OVNDbKeyPath
 	templateParameters["OVNDB_CACERT_PATH"] = ovn_common.OVNDbCaCertPath
+	templateParameters["OVSDB_SERVER_OPTIONS"] = instance.Spec.ExtraArgs.ServerOptions
+	unixctlCommands := []string{}
+	for _, cmd := range instance.Spec.ExtraArgs.Unixctl {
+		unixctlCommands = append(unixctlCommands, strings.Join(append([]string{cmd.Command}, cmd.Args...), " "))
+	}
+	templateParameters["UNIXCTL_COMMANDS"] = unixctlCommands
 
 	cms := []util.Template{
 		// ScriptsConfigMap
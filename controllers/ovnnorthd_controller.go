@@ -19,12 +19,16 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,24 +41,34 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/openstack-k8s-operators/lib-common/modules/common"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/deployment"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
 	common_rbac "github.com/openstack-k8s-operators/lib-common/modules/common/rbac"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	ovn_metrics "github.com/openstack-k8s-operators/ovn-operator/pkg/metrics"
 	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovnnorthd"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_labels "k8s.io/apimachinery/pkg/labels"
 )
 
 // OVNNorthdReconciler reconciles a OVNNorthd object
 type OVNNorthdReconciler struct {
 	client.Client
-	Kclient kubernetes.Interface
-	Scheme  *runtime.Scheme
+	Kclient    kubernetes.Interface
+	Scheme     *runtime.Scheme
+	RestConfig *rest.Config
+	Recorder   record.EventRecorder
 }
 
 // GetClient -
@@ -80,7 +94,10 @@ func (r *OVNNorthdReconciler) GetLogger(ctx context.Context) logr.Logger {
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;patch;update;delete;
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;patch;update;delete;
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;
+//+kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create;
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;
 
 // service account, role, rolebinding
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
@@ -133,11 +150,15 @@ func (r *OVNNorthdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// initialize conditions used later as Status=Unknown
 	cl := condition.CreateList(
 		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
 		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
 		condition.UnknownCondition(condition.ServiceAccountReadyCondition, condition.InitReason, condition.ServiceAccountReadyInitMessage),
 		condition.UnknownCondition(condition.RoleReadyCondition, condition.InitReason, condition.RoleReadyInitMessage),
 		condition.UnknownCondition(condition.RoleBindingReadyCondition, condition.InitReason, condition.RoleBindingReadyInitMessage),
 		condition.UnknownCondition(condition.TLSInputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(ovnv1.VersionCompatibleCondition, condition.InitReason, ovnv1.VersionCompatibleInitMessage),
+		dbReadyInitCondition(ovnv1.NBDBType),
+		dbReadyInitCondition(ovnv1.SBDBType),
 	)
 
 	instance.Status.Conditions.Init(&cl)
@@ -158,6 +179,15 @@ func (r *OVNNorthdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 				instance.Status.Conditions.Mirror(condition.ReadyCondition))
 		}
 		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+		recordConditionEvents(r.Recorder, instance, savedConditions, instance.Status.Conditions)
+
+		name := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+		if instance.DeletionTimestamp.IsZero() {
+			ovn_metrics.ObserveReconcile("OVNNorthd", name, instance.Status.Conditions, _err)
+		} else {
+			ovn_metrics.Forget("OVNNorthd", name)
+		}
+
 		err := helper.PatchInstance(ctx, instance)
 		if err != nil {
 			_err = err
@@ -205,10 +235,13 @@ func (r *OVNNorthdReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}); err != nil {
 		return err
 	}
+	ovn_metrics.Register()
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ovnv1.OVNNorthd{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
@@ -336,13 +369,18 @@ func (r *OVNNorthdReconciler) reconcileNormal(ctx context.Context, instance *ovn
 		return ctrlResult, nil
 	}
 
-	nbEndpoint, err := getInternalEndpoint(ctx, helper, instance, ovnv1.NBDBType)
+	_, nbEndpoint, err := getDBClusterEndpoint(ctx, helper, &instance.Status.Conditions, instance.Namespace, ovnv1.NBDBType)
 	if err != nil {
-		return ctrlResult, err
+		return ctrl.Result{}, err
 	}
-	sbEndpoint, err := getInternalEndpoint(ctx, helper, instance, ovnv1.SBDBType)
+	sbCluster, sbEndpoint, err := getDBClusterEndpoint(ctx, helper, &instance.Status.Conditions, instance.Namespace, ovnv1.SBDBType)
 	if err != nil {
-		return ctrlResult, err
+		return ctrl.Result{}, err
+	}
+	if nbEndpoint == "" || sbEndpoint == "" {
+		// the OVNDBCluster watch triggers the next reconcile
+		Log.Info("Waiting for the OVNDBCluster endpoints")
+		return ctrl.Result{}, nil
 	}
 
 	envVars := make(map[string]env.Setter)
@@ -408,11 +446,75 @@ func (r *OVNNorthdReconciler) reconcileNormal(ctx context.Context, instance *ovn
 	// all cert input checks out so report InputReady
 	instance.Status.Conditions.MarkTrue(condition.TLSInputReadyCondition, condition.InputReadyMessage)
 
+	//
+	// create Configmap required for OVNNorthd input
+	// - %-scripts configmap holding the probe scripts
+	//
+	err = r.generateServiceConfigMaps(ctx, helper, instance, &envVars)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	instance.Status.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
+
+	// the log levels are read on pod start and changed at runtime, so they
+	// are not part of the deployment
+	err = r.ensureRuntimeConfigMap(ctx, helper, instance)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	err = ovn_common.EnsurePodDisruptionBudget(ctx, helper, ovnv1.ServiceNameOVNNorthd,
+		ovnnorthd.PodDisruptionBudget(instance, serviceLabels))
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// the override is part of the inputs, changing it restarts the pods
+	inputVars := util.MergeMaps(map[string]env.Setter{}, envVars)
+	if instance.Spec.Override.Deployment != nil {
+		hash, err := util.ObjectHash(instance.Spec.Override.Deployment)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		inputVars[ovn_common.OverrideHashKey] = env.SetValue(hash)
+	}
+	inputHash, err := r.createHashOfInputHashes(ctx, instance, inputVars)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	envVars["CONFIG_HASH"] = env.SetValue(inputHash)
+
 	// Define a new Deployment object
-	depl := deployment.NewDeployment(
-		ovnnorthd.Deployment(instance, serviceLabels, nbEndpoint, sbEndpoint, envVars),
-		time.Duration(5)*time.Second,
-	)
+	deplDef := ovnnorthd.Deployment(instance, serviceLabels, nbEndpoint, sbEndpoint, envVars)
+	err = ovn_common.ApplyOverride(deplDef, instance.Spec.Override.Deployment)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	depl := deployment.NewDeployment(deplDef, time.Duration(5)*time.Second)
 
 	ctrlResult, err = depl.CreateOrPatch(ctx, helper)
 	if err != nil {
@@ -423,7 +525,11 @@ func (r *OVNNorthdReconciler) reconcileNormal(ctx context.Context, instance *ovn
 			condition.DeploymentReadyErrorMessage,
 			err.Error()))
 		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
+	}
+	instance.Status.NBEndpoint = nbEndpoint
+	instance.Status.SBEndpoint = sbEndpoint
+	instance.Status.NThreads = ovnnorthd.NThreads(instance)
+	if (ctrlResult != ctrl.Result{}) {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.DeploymentReadyCondition,
 			condition.RequestedReason,
@@ -436,28 +542,221 @@ func (r *OVNNorthdReconciler) reconcileNormal(ctx context.Context, instance *ovn
 
 	if instance.Status.ReadyCount > 0 {
 		instance.Status.Conditions.MarkTrue(condition.DeploymentReadyCondition, condition.DeploymentReadyMessage)
-	} else if *instance.Spec.Replicas == 0 {
+	} else if instance.Spec.GetReplicas() == 0 {
 		instance.Status.Conditions.Remove(condition.DeploymentReadyCondition)
 	}
 	// create Deployment - end
 
+	// apply log level changes to the running pods without a restart
+	podList, err := helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: k8s_labels.Set(serviceLabels).String(),
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var result ctrl.Result
+	instance.Status.LogLevels, result = reconcileLogLevels(
+		ctx, helper, r.RestConfig, podList.Items, ovnv1.ServiceNameOVNNorthd, ovnnorthd.AppctlCommand,
+		instance.Spec.LogLevel, instance.Spec.LogModules, instance.Status.LogLevels)
+
+	// ovn-northd must not run a newer release than the OVN_Southbound database
+	instance.Status.Versions = reconcileVersions(
+		ctx, helper, r.RestConfig, podList.Items, ovnnorthd.VersionProbes, instance.Status.Versions)
+	skewErr := ovn_common.CheckVersionSkew(
+		instance.Status.Versions, ovn_common.ComponentOVNNorthd, sbCluster.Status.Versions, ovn_common.ComponentOVN)
+	instance.Status.Conditions.Set(versionCompatibleCondition(skewErr))
+
+	r.reconcileActiveInstance(ctx, helper, instance, podList.Items)
+	r.reconcilePerformance(ctx, helper, instance, podList.Items)
+
 	Log.Info("Reconciled Service successfully")
-	return ctrl.Result{}, nil
+	if instance.Spec.GetReplicas() > 1 {
+		// failovers don't change any watched resource
+		if result.RequeueAfter == 0 || ovnnorthd.StatusCheckInterval < result.RequeueAfter {
+			result.RequeueAfter = ovnnorthd.StatusCheckInterval
+		}
+	}
+	if instance.Spec.Metrics.Enabled {
+		interval := time.Duration(instance.Spec.Metrics.IntervalSeconds) * time.Second
+		if result.RequeueAfter == 0 || interval < result.RequeueAfter {
+			result.RequeueAfter = interval
+		}
+	}
+	return result, nil
 }
 
-func getInternalEndpoint(
+// reconcilePerformance - report the loop and processing times sampled by the
+// metrics sidecar of each running pod
+func (r *OVNNorthdReconciler) reconcilePerformance(
 	ctx context.Context,
 	h *helper.Helper,
 	instance *ovnv1.OVNNorthd,
-	dbType string,
+	pods []corev1.Pod,
+) {
+	Log := r.GetLogger(ctx)
+
+	if !instance.Spec.Metrics.Enabled {
+		instance.Status.Performance = nil
+		return
+	}
+
+	performance := map[string]ovnv1.OVNNorthdPerformance{}
+	for i, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		out, err := ovn_common.ExecInPod(ctx, h.GetKClient(), r.RestConfig, &pods[i],
+			ovnnorthd.MetricsContainer, ovnnorthd.StopwatchCommand)
+		if err != nil {
+			Log.Info(fmt.Sprintf("Unable to get the stopwatches of %s: %s", pod.Name, err))
+			continue
+		}
+		stopwatches, err := ovnnorthd.ParseStopwatches(out)
+		if err != nil {
+			Log.Info(fmt.Sprintf("Unable to get the stopwatches of %s: %s", pod.Name, err))
+			continue
+		}
+		performance[pod.Name] = ovnnorthd.Performance(stopwatches)
+	}
+	instance.Status.Performance = performance
+}
+
+// reconcileActiveInstance - query the running ovn-northd instances for the
+// OVN_Southbound lock and report the active and standby instances. The
+// NorthdActive condition is only set once at least one instance reported its
+// state.
+func (r *OVNNorthdReconciler) reconcileActiveInstance(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNNorthd,
+	pods []corev1.Pod,
+) {
+	Log := r.GetLogger(ctx)
+
+	active := []string{}
+	standby := []string{}
+	reported := false
+	for i, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		out, err := ovn_common.ExecInPod(ctx, h.GetKClient(), r.RestConfig, &pods[i],
+			ovnv1.ServiceNameOVNNorthd, ovnnorthd.StatusCommand)
+		if err != nil {
+			Log.Info(fmt.Sprintf("Unable to get the status of %s: %s", pod.Name, err))
+			continue
+		}
+		state, err := ovnnorthd.ParseStatus(out)
+		if err != nil {
+			Log.Info(fmt.Sprintf("Unable to get the status of %s: %s", pod.Name, err))
+			continue
+		}
+		reported = true
+		switch state {
+		case ovnnorthd.StatusActive:
+			active = append(active, pod.Name)
+		case ovnnorthd.StatusStandby:
+			standby = append(standby, pod.Name)
+		}
+	}
+	sort.Strings(active)
+	sort.Strings(standby)
+
+	instance.Status.ActiveInstance = ""
+	instance.Status.StandbyInstances = standby
+	if !reported {
+		return
+	}
+	switch len(active) {
+	case 0:
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.NorthdActiveCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.NorthdNoActiveMessage))
+	case 1:
+		instance.Status.ActiveInstance = active[0]
+		instance.Status.Conditions.MarkTrue(ovnv1.NorthdActiveCondition, ovnv1.NorthdActiveReadyMessage, active[0])
+	default:
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			ovnv1.NorthdActiveCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			ovnv1.NorthdMultipleActiveMessage,
+			strings.Join(active, ", ")))
+	}
+}
+
+// generateServiceConfigMaps - create configmaps which hold scripts and service configuration
+func (r *OVNNorthdReconciler) generateServiceConfigMaps(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNNorthd,
+	envVars *map[string]env.Setter,
+) error {
+	// Create/update configmaps from templates
+	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(ovnv1.ServiceNameOVNNorthd), map[string]string{})
+
+	templateParameters := map[string]interface{}{
+		"UnixctlSocket": ovnnorthd.UnixctlSocket,
+		"StopwatchFile": ovnnorthd.StopwatchFile,
+	}
+	cms := []util.Template{
+		// ScriptsConfigMap
+		{
+			Name:          fmt.Sprintf("%s-scripts", instance.Name),
+			Namespace:     instance.Namespace,
+			Type:          util.TemplateTypeScripts,
+			InstanceType:  instance.Kind,
+			Labels:        cmLabels,
+			ConfigOptions: templateParameters,
+		},
+	}
+	return configmap.EnsureConfigMaps(ctx, h, instance, cms, envVars)
+}
+
+// ensureRuntimeConfigMap - create the ConfigMap holding the settings read by the
+// pods at runtime. It is not part of the deployment so changes don't trigger a rollout.
+func (r *OVNNorthdReconciler) ensureRuntimeConfigMap(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *ovnv1.OVNNorthd,
+) error {
+	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(ovnv1.ServiceNameOVNNorthd), map[string]string{})
+
+	cms := []util.Template{
+		{
+			Name:         instance.Name + ovn_common.RuntimeConfigMapSuffix,
+			Namespace:    instance.Namespace,
+			Type:         util.TemplateTypeNone,
+			InstanceType: instance.Kind,
+			Labels:       cmLabels,
+			CustomData: map[string]string{
+				ovn_common.LogLevelKey:   instance.Spec.LogLevel,
+				ovn_common.LogModulesKey: ovn_common.LogModulesValue(instance.Spec.LogModules),
+			},
+		},
+	}
+	return configmap.EnsureConfigMaps(ctx, h, instance, cms, nil)
+}
+
+// createHashOfInputHashes - creates a hash of the hashes of all the inputs of
+// the deployment
+func (r *OVNNorthdReconciler) createHashOfInputHashes(
+	ctx context.Context,
+	instance *ovnv1.OVNNorthd,
+	envVars map[string]env.Setter,
 ) (string, error) {
-	cluster, err := ovnv1.GetDBClusterByType(ctx, h, instance.Namespace, map[string]string{}, dbType)
+	Log := r.GetLogger(ctx)
+
+	mergedMapVars := env.MergeEnvs([]corev1.EnvVar{}, envVars)
+	hash, err := util.ObjectHash(mergedMapVars)
 	if err != nil {
-		return "", err
+		return hash, err
 	}
-	internalEndpoint, err := cluster.GetInternalEndpoint()
-	if err != nil {
-		return "", err
+	if hashMap, changed := util.SetHash(instance.Status.Hash, common.InputHashName, hash); changed {
+		instance.Status.Hash = hashMap
+		Log.Info(fmt.Sprintf("Input maps hash %s - %s", common.InputHashName, hash))
 	}
-	return internalEndpoint, nil
+	return hash, nil
 }
@@ -116,25 +116,31 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&controllers.OVNNorthdReconciler{
-		Client:  mgr.GetClient(),
-		Scheme:  mgr.GetScheme(),
-		Kclient: kclient,
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Kclient:    kclient,
+		RestConfig: cfg,
+		Recorder:   mgr.GetEventRecorderFor("ovnnorthd-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OVNNorthd")
 		os.Exit(1)
 	}
 	if err = (&controllers.OVNDBClusterReconciler{
-		Client:  mgr.GetClient(),
-		Kclient: kclient,
-		Scheme:  mgr.GetScheme(),
+		Client:     mgr.GetClient(),
+		Kclient:    kclient,
+		Scheme:     mgr.GetScheme(),
+		RestConfig: cfg,
+		Recorder:   mgr.GetEventRecorderFor("ovndbcluster-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OVNDBCluster")
 		os.Exit(1)
 	}
 	if err = (&controllers.OVNControllerReconciler{
-		Client:  mgr.GetClient(),
-		Kclient: kclient,
-		Scheme:  mgr.GetScheme(),
+		Client:     mgr.GetClient(),
+		Kclient:    kclient,
+		Scheme:     mgr.GetScheme(),
+		RestConfig: cfg,
+		Recorder:   mgr.GetEventRecorderFor("ovncontroller-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OVNController")
 		os.Exit(1)
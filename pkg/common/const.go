@@ -4,4 +4,23 @@ const (
 	OVNDbCertPath   string = "/etc/pki/tls/certs/ovndb.crt"
 	OVNDbKeyPath    string = "/etc/pki/tls/private/ovndb.key"
 	OVNDbCaCertPath string = "/etc/pki/tls/certs/ovndbca.crt"
+
+	// RuntimeConfigMapSuffix - suffix of the ConfigMap holding settings which
+	// are read by the pods at runtime and must not trigger a rollout
+	RuntimeConfigMapSuffix string = "-runtime"
+	// LogLevelKey - key of the runtime ConfigMap holding the console log level
+	LogLevelKey string = "log-level"
+	// LogModulesKey - key of the runtime ConfigMap holding the per module log levels
+	LogModulesKey string = "log-modules"
+
+	// ComponentOVN - OVN release of the database pods, reported by ovn-(nb|sb)ctl
+	ComponentOVN string = "ovn"
+	// ComponentOVSDBServer - ovsdb-server of the database and OVS pods
+	ComponentOVSDBServer string = "ovsdb-server"
+	// ComponentOVNNorthd - ovn-northd
+	ComponentOVNNorthd string = "ovn-northd"
+	// ComponentOVNController - ovn-controller
+	ComponentOVNController string = "ovn-controller"
+	// ComponentOVSVswitchd - ovs-vswitchd
+	ComponentOVSVswitchd string = "ovs-vswitchd"
 )
@@ -0,0 +1,52 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+const (
+	// OverrideHashKey - key of the override hash in the input hashes
+	OverrideHashKey = "OverrideHash"
+)
+
+// ApplyOverride - applies the strategic merge patch override on top of the
+// generated workload obj
+func ApplyOverride[T any](obj *T, override *runtime.RawExtension) error {
+	if override == nil || len(override.Raw) == 0 {
+		return nil
+	}
+
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, override.Raw, obj)
+	if err != nil {
+		return fmt.Errorf("error applying override: %w", err)
+	}
+
+	// decode into a new object so fields removed by the patch are dropped
+	result := new(T)
+	if err := json.Unmarshal(patched, result); err != nil {
+		return fmt.Errorf("error applying override: %w", err)
+	}
+	*obj = *result
+
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// PodDisruptionBudgetSpec - returns the PodDisruptionBudget spec for the pods
+// matching labels. minAvailable or maxUnavailable are the values computed
+// for the service, a nil spec means no PodDisruptionBudget is required.
+// Values set in override replace the computed ones.
+func PodDisruptionBudgetSpec(
+	labels map[string]string,
+	minAvailable *intstr.IntOrString,
+	maxUnavailable *intstr.IntOrString,
+	override *ovnv1.PodDisruptionBudgetOverrideSpec,
+) *policyv1.PodDisruptionBudgetSpec {
+	if override != nil {
+		if override.Enabled != nil && !*override.Enabled {
+			return nil
+		}
+		if override.MinAvailable != nil || override.MaxUnavailable != nil {
+			minAvailable = override.MinAvailable
+			maxUnavailable = override.MaxUnavailable
+		}
+	}
+	if minAvailable == nil && maxUnavailable == nil {
+		return nil
+	}
+
+	return &policyv1.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+		MinAvailable:   minAvailable,
+		MaxUnavailable: maxUnavailable,
+	}
+}
+
+// EnsurePodDisruptionBudget - create or patch the PodDisruptionBudget owned by
+// the instance of the helper, or delete it if spec is nil. A
+// PodDisruptionBudget with the same name which is not owned by the instance
+// is never deleted.
+func EnsurePodDisruptionBudget(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	spec *policyv1.PodDisruptionBudgetSpec,
+) error {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: h.GetBeforeObject().GetNamespace(),
+		},
+	}
+
+	if spec == nil {
+		err := h.GetClient().Get(ctx, client.ObjectKeyFromObject(pdb), pdb)
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error getting PodDisruptionBudget %s: %w", name, err)
+		}
+		if !metav1.IsControlledBy(pdb, h.GetBeforeObject()) {
+			h.GetLogger().Info(fmt.Sprintf("PodDisruptionBudget %s is not owned by %s, not deleting it", name, h.GetBeforeObject().GetName()))
+			return nil
+		}
+		err = h.GetClient().Delete(ctx, pdb, client.Preconditions{UID: &pdb.UID})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return fmt.Errorf("error deleting PodDisruptionBudget %s: %w", name, err)
+		}
+		return nil
+	}
+
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), pdb, func() error {
+		pdb.Labels = spec.Selector.MatchLabels
+		pdb.Spec = *spec
+		return controllerutil.SetControllerReference(h.GetBeforeObject(), pdb, h.GetScheme())
+	})
+	if err != nil {
+		return fmt.Errorf("error creating or patching PodDisruptionBudget %s: %w", name, err)
+	}
+	if op != controllerutil.OperationResultNone {
+		h.GetLogger().Info(fmt.Sprintf("PodDisruptionBudget %s successfully reconciled - operation: %s", name, string(op)))
+	}
+
+	return nil
+}
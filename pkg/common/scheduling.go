@@ -0,0 +1,65 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodAntiAffinity - returns the rule distributing the pods of serviceName
+// across worker nodes. With AntiAffinityRequired two pods are never scheduled
+// on the same node, otherwise they still get created on the same node if
+// there is no other choice.
+func PodAntiAffinity(serviceName string, antiAffinity string) *corev1.Affinity {
+	podAffinity := affinity.DistributePods(
+		common.AppSelector,
+		[]string{
+			serviceName,
+		},
+		corev1.LabelHostname,
+	)
+	if antiAffinity == ovnv1.AntiAffinityRequired {
+		preferred := podAffinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+		podAffinity.PodAntiAffinity = &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				preferred[0].PodAffinityTerm,
+			},
+		}
+	}
+	return podAffinity
+}
+
+// TopologySpreadConstraints - returns the constraints with the pods matching
+// labels selected where the constraint has no label selector
+func TopologySpreadConstraints(
+	constraints []corev1.TopologySpreadConstraint,
+	labels map[string]string,
+) []corev1.TopologySpreadConstraint {
+	if len(constraints) == 0 {
+		return nil
+	}
+	result := make([]corev1.TopologySpreadConstraint, len(constraints))
+	for i, c := range constraints {
+		c.DeepCopyInto(&result[i])
+		if result[i].LabelSelector == nil {
+			result[i].LabelSelector = &metav1.LabelSelector{
+				MatchLabels: labels,
+			}
+		}
+	}
+	return result
+}
@@ -12,6 +12,10 @@ limitations under the License.
 
 package common
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
 // Helper function while can't use buildin min
 // TOOD(averdagu) remove when using go1.21.0
 func Min(x, y int) int {
@@ -20,3 +24,15 @@ func Min(x, y int) int {
 	}
 	return y
 }
+
+// ContainerResources - returns the resources of a container, falling back to
+// the resources shared by all containers of the service
+func ContainerResources(
+	resources *corev1.ResourceRequirements,
+	shared corev1.ResourceRequirements,
+) corev1.ResourceRequirements {
+	if resources != nil {
+		return *resources
+	}
+	return shared
+}
@@ -0,0 +1,160 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// versionRegex matches the version in the first line of the --version output,
+// e.g. "ovn-northd 24.03.4" or "ovs-vswitchd (Open vSwitch) 3.3.1"
+var versionRegex = regexp.MustCompile(`(\d+)\.(\d+)(\.\d+)?`)
+
+// VersionProbe - describes how to query the version of a component
+type VersionProbe struct {
+	Component string
+	Container string
+	Command   []string
+}
+
+// ParseVersion - returns the version from the output of --version
+func ParseVersion(output string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	return versionRegex.FindString(line)
+}
+
+// ComponentVersions - returns the versions of the components running in pods,
+// grouped by the image of the container. The version of an image is only
+// queried once, versions already known from previous are reused. Images
+// whose version can't be queried yet are reported with an empty version.
+func ComponentVersions(
+	ctx context.Context,
+	kclient kubernetes.Interface,
+	config *rest.Config,
+	pods []corev1.Pod,
+	probes []VersionProbe,
+	previous []ovnv1.ComponentVersion,
+) ([]ovnv1.ComponentVersion, error) {
+	known := map[string]string{}
+	for _, v := range previous {
+		if v.Version != "" {
+			known[v.Component+"/"+v.ImageDigest] = v.Version
+		}
+	}
+
+	versions := []ovnv1.ComponentVersion{}
+	var errs []string
+	for _, probe := range probes {
+		counts := map[string]int32{}
+		podForDigest := map[string]*corev1.Pod{}
+		for i, pod := range pods {
+			if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+				continue
+			}
+			for _, cs := range pod.Status.ContainerStatuses {
+				if cs.Name != probe.Container || cs.ImageID == "" {
+					continue
+				}
+				counts[cs.ImageID]++
+				if _, ok := podForDigest[cs.ImageID]; !ok && cs.Ready {
+					podForDigest[cs.ImageID] = &pods[i]
+				}
+			}
+		}
+
+		digests := make([]string, 0, len(counts))
+		for digest := range counts {
+			digests = append(digests, digest)
+		}
+		sort.Strings(digests)
+		for _, digest := range digests {
+			version, ok := known[probe.Component+"/"+digest]
+			if !ok && podForDigest[digest] != nil {
+				out, err := ExecInPod(ctx, kclient, config, podForDigest[digest], probe.Container, probe.Command)
+				if err != nil {
+					errs = append(errs, err.Error())
+				} else {
+					version = ParseVersion(out)
+				}
+			}
+			versions = append(versions, ovnv1.ComponentVersion{
+				Component:   probe.Component,
+				Version:     version,
+				ImageDigest: digest,
+				Pods:        counts[digest],
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return versions, fmt.Errorf("unable to query versions: %s", strings.Join(errs, "; "))
+	}
+	return versions, nil
+}
+
+// minorVersion - returns the major and minor number of a version
+func minorVersion(version string) (int, int, bool) {
+	match := versionRegex.FindStringSubmatch(version)
+	if match == nil {
+		return 0, 0, false
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	return major, minor, true
+}
+
+// NewerRelease - returns true if version a belongs to a newer release
+// (major.minor) than version b. Unknown versions are never newer.
+func NewerRelease(a string, b string) bool {
+	aMajor, aMinor, aOk := minorVersion(a)
+	bMajor, bMinor, bOk := minorVersion(b)
+	if !aOk || !bOk {
+		return false
+	}
+	if aMajor != bMajor {
+		return aMajor > bMajor
+	}
+	return aMinor > bMinor
+}
+
+// CheckVersionSkew - returns an error if any version of component is a newer
+// release than any version of reference, e.g. ovn-northd running a newer
+// release than the OVN_Southbound database.
+func CheckVersionSkew(
+	versions []ovnv1.ComponentVersion,
+	component string,
+	referenceVersions []ovnv1.ComponentVersion,
+	reference string,
+) error {
+	for _, v := range versions {
+		if v.Component != component {
+			continue
+		}
+		for _, r := range referenceVersions {
+			if r.Component == reference && NewerRelease(v.Version, r.Version) {
+				return fmt.Errorf("%s %s is newer than %s %s", component, v.Version, reference, r.Version)
+			}
+		}
+	}
+	return nil
+}
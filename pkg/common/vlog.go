@@ -0,0 +1,185 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+)
+
+// RuntimeConfigMapEnv - returns an env var set from key of the runtime
+// ConfigMap. It is resolved on container start only, so changing it does not
+// trigger a rollout.
+func RuntimeConfigMapEnv(instanceName string, key string) env.Setter {
+	return func(e *corev1.EnvVar) {
+		e.Value = ""
+		e.ValueFrom = &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: instanceName + RuntimeConfigMapSuffix,
+				},
+				Key:      key,
+				Optional: ptr.To(true),
+			},
+		}
+	}
+}
+
+// LogLevelSpec - returns the canonical representation of a log level and per
+// module log levels, e.g. "info jsonrpc:info raft:dbg"
+func LogLevelSpec(level string, modules map[string]string) string {
+	spec := []string{strings.ToLower(level)}
+	names := make([]string, 0, len(modules))
+	for module := range modules {
+		names = append(names, module)
+	}
+	sort.Strings(names)
+	for _, module := range names {
+		// modules at the global level are not reported by ParseVlogList
+		if l := strings.ToLower(modules[module]); l != spec[0] {
+			spec = append(spec, fmt.Sprintf("%s:%s", module, l))
+		}
+	}
+	return strings.Join(spec, " ")
+}
+
+// LogModulesValue - returns the per module log levels as space separated
+// module:level list as passed to the pods
+func LogModulesValue(modules map[string]string) string {
+	spec := LogLevelSpec("", modules)
+	return strings.TrimSpace(spec)
+}
+
+// ParseVlogList - parses the output of vlog/list and returns the console
+// log levels in the format of LogLevelSpec. The global level is the level of
+// most of the modules without a dedicated level in modules, the ones at a
+// different level, e.g. changed manually through vlog/set, are reported as
+// well.
+func ParseVlogList(output string, modules map[string]string) (string, error) {
+	levels := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		// module console syslog file
+		if len(fields) != 4 || fields[1] == "console" || strings.HasPrefix(fields[1], "-") {
+			continue
+		}
+		levels[fields[0]] = strings.ToLower(fields[1])
+	}
+	if len(levels) == 0 {
+		return "", fmt.Errorf("unable to parse vlog/list output")
+	}
+
+	counts := map[string]int{}
+	for module, level := range levels {
+		if _, ok := modules[module]; !ok {
+			counts[level]++
+		}
+	}
+	global := ""
+	for level, count := range counts {
+		if count > counts[global] || (count == counts[global] && level < global) {
+			global = level
+		}
+	}
+
+	effective := map[string]string{}
+	for module, level := range levels {
+		if _, ok := modules[module]; ok || level != global {
+			effective[module] = level
+		}
+	}
+	return LogLevelSpec(global, effective), nil
+}
+
+// LogLevelsCheckInterval - how often the effective log levels are read back
+// from the pods to detect levels changed manually through vlog/set
+const LogLevelsCheckInterval = 10 * time.Minute
+
+// LogLevelsCheckDue - whether the log levels of the pod have to be read or
+// applied: nothing was recorded for the pod yet, the pod was recreated, the
+// recorded levels differ from desired or they were last read more than
+// LogLevelsCheckInterval ago. Otherwise it returns the time until the next
+// check.
+func LogLevelsCheckDue(pod *corev1.Pod, recorded *ovnv1.PodLogLevels, desired string, now time.Time) (bool, time.Duration) {
+	if recorded == nil || recorded.UID != pod.UID || recorded.Levels != desired {
+		return true, 0
+	}
+	next := recorded.CheckTime.Add(LogLevelsCheckInterval).Sub(now)
+	if next <= 0 {
+		return true, 0
+	}
+	return false, next
+}
+
+// GetLogLevels - returns the effective console log levels of a running
+// OVS/OVN daemon as reported by vlog/list. appctl is the command used to reach
+// the daemon, e.g. ovs-appctl -t <ctl>.
+func GetLogLevels(
+	ctx context.Context,
+	kclient kubernetes.Interface,
+	config *rest.Config,
+	pod *corev1.Pod,
+	container string,
+	appctl string,
+	modules map[string]string,
+) (string, error) {
+	out, err := ExecInPod(ctx, kclient, config, pod, container,
+		[]string{"/bin/sh", "-c", fmt.Sprintf("%s vlog/list", appctl)})
+	if err != nil {
+		return "", err
+	}
+	return ParseVlogList(out, modules)
+}
+
+// ApplyLogLevels - sets the console log level and per module log levels of a
+// running OVS/OVN daemon through vlog/set and returns the effective levels.
+// appctl is the command used to reach the daemon, e.g. ovs-appctl -t <ctl>.
+func ApplyLogLevels(
+	ctx context.Context,
+	kclient kubernetes.Interface,
+	config *rest.Config,
+	pod *corev1.Pod,
+	container string,
+	appctl string,
+	level string,
+	modules map[string]string,
+) (string, error) {
+	// setting the global level first resets modules which were removed
+	cmds := []string{fmt.Sprintf("%s vlog/set console:%s", appctl, level)}
+	names := make([]string, 0, len(modules))
+	for module := range modules {
+		names = append(names, module)
+	}
+	sort.Strings(names)
+	for _, module := range names {
+		cmds = append(cmds, fmt.Sprintf("%s vlog/set %s:console:%s", appctl, module, modules[module]))
+	}
+	cmds = append(cmds, fmt.Sprintf("%s vlog/list", appctl))
+
+	out, err := ExecInPod(ctx, kclient, config, pod, container,
+		[]string{"/bin/sh", "-c", strings.Join(cmds, " && ")})
+	if err != nil {
+		return "", err
+	}
+	return ParseVlogList(out, modules)
+}
@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const vlogList = `                 console    syslog    file
+                 -------    ------    ------
+backtrace          INFO       ERR       INFO
+jsonrpc            INFO       ERR       INFO
+ovsdb_server       INFO       ERR       INFO
+raft               DBG        ERR       INFO
+reconnect          INFO       ERR       INFO
+`
+
+func TestParseVlogList(t *testing.T) {
+	g := NewWithT(t)
+
+	levels, err := ParseVlogList(vlogList, map[string]string{"raft": "dbg"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(levels).To(Equal("info raft:dbg"))
+	g.Expect(levels).To(Equal(LogLevelSpec("info", map[string]string{"raft": "dbg"})))
+
+	// a module changed manually through vlog/set
+	levels, err = ParseVlogList(vlogList, map[string]string{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(levels).To(Equal("info raft:dbg"))
+	g.Expect(levels).ToNot(Equal(LogLevelSpec("info", map[string]string{})))
+
+	_, err = ParseVlogList("ovs-appctl: cannot connect", map[string]string{})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestLogLevelsCheckDue(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ovsdbserver-nb-0", UID: "uid-0"}}
+	recorded := &ovnv1.PodLogLevels{
+		Levels:    "info raft:dbg",
+		UID:       "uid-0",
+		CheckTime: metav1.NewTime(now.Add(-time.Minute)),
+	}
+
+	due, next := LogLevelsCheckDue(pod, recorded, "info raft:dbg", now)
+	g.Expect(due).To(BeFalse())
+	g.Expect(next).To(Equal(LogLevelsCheckInterval - time.Minute))
+
+	// nothing recorded yet
+	due, _ = LogLevelsCheckDue(pod, nil, "info raft:dbg", now)
+	g.Expect(due).To(BeTrue())
+
+	// the spec changed
+	due, _ = LogLevelsCheckDue(pod, recorded, "dbg", now)
+	g.Expect(due).To(BeTrue())
+
+	// the pod was recreated
+	recreated := pod.DeepCopy()
+	recreated.UID = "uid-1"
+	due, _ = LogLevelsCheckDue(recreated, recorded, "info raft:dbg", now)
+	g.Expect(due).To(BeTrue())
+
+	// the levels are read back periodically
+	due, _ = LogLevelsCheckDue(pod, recorded, "info raft:dbg", now.Add(LogLevelsCheckInterval))
+	g.Expect(due).To(BeTrue())
+}
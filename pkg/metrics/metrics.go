@@ -0,0 +1,233 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics provides the operator level Prometheus metrics about the
+// state of the OVN resources. The reconcilers record the state at the end of
+// every reconcile, ages are computed when the metrics are scraped.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "ovn_operator"
+
+var (
+	readyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "resource_ready"),
+		"Whether the Ready condition of the resource is true.",
+		[]string{"kind", "namespace", "name"}, nil)
+	conditionAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "condition_age_seconds"),
+		"Time since the last transition of the condition.",
+		[]string{"kind", "namespace", "name", "condition", "status"}, nil)
+	lastSuccessDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "seconds_since_last_successful_reconcile"),
+		"Time since the last reconcile of the resource which finished without error.",
+		[]string{"kind", "namespace", "name"}, nil)
+	desiredMembersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "dbcluster", "desired_members"),
+		"Number of database members requested by the OVNDBCluster.",
+		[]string{"namespace", "name"}, nil)
+	readyMembersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "dbcluster", "ready_members"),
+		"Number of ready database members of the OVNDBCluster.",
+		[]string{"namespace", "name"}, nil)
+	nodeConfigAppliedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ovncontroller", "node_config_applied"),
+		"Whether the current configuration is applied to the ovn-controller pod on the node.",
+		[]string{"namespace", "name", "node"}, nil)
+	nodeExternalIDsDriftDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "ovncontroller", "node_external_ids_drift"),
+		"Number of external-ids on the node which differ from the spec.",
+		[]string{"namespace", "name", "node"}, nil)
+)
+
+type resourceKey struct {
+	kind string
+	name types.NamespacedName
+}
+
+type resourceState struct {
+	ready          bool
+	conditions     condition.Conditions
+	lastSuccess    time.Time
+	members        bool
+	desiredMembers int32
+	readyMembers   int32
+	nodes          map[string]nodeState
+}
+
+// nodeState - state of the node configuration of an ovs pod
+type nodeState struct {
+	applied bool
+	drifted int
+}
+
+// collector - prometheus.Collector reporting the recorded resource states
+type collector struct {
+	mu        sync.Mutex
+	resources map[resourceKey]*resourceState
+}
+
+var (
+	stateCollector = &collector{resources: map[resourceKey]*resourceState{}}
+	registerOnce   sync.Once
+)
+
+// Register - registers the collector with the controller-runtime metrics
+// registry. It is called by every reconciler and only registers once.
+func Register() {
+	registerOnce.Do(func() {
+		ctrlmetrics.Registry.MustRegister(stateCollector)
+	})
+}
+
+// Describe implements prometheus.Collector
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- readyDesc
+	ch <- conditionAgeDesc
+	ch <- lastSuccessDesc
+	ch <- desiredMembersDesc
+	ch <- readyMembersDesc
+	ch <- nodeConfigAppliedDesc
+	ch <- nodeExternalIDsDriftDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, state := range c.resources {
+		ns, name := key.name.Namespace, key.name.Name
+
+		ch <- prometheus.MustNewConstMetric(readyDesc, prometheus.GaugeValue,
+			boolToFloat(state.ready), key.kind, ns, name)
+		for _, cond := range state.conditions {
+			ch <- prometheus.MustNewConstMetric(conditionAgeDesc, prometheus.GaugeValue,
+				now.Sub(cond.LastTransitionTime.Time).Seconds(),
+				key.kind, ns, name, string(cond.Type), string(cond.Status))
+		}
+		if !state.lastSuccess.IsZero() {
+			ch <- prometheus.MustNewConstMetric(lastSuccessDesc, prometheus.GaugeValue,
+				now.Sub(state.lastSuccess).Seconds(), key.kind, ns, name)
+		}
+		if state.members {
+			ch <- prometheus.MustNewConstMetric(desiredMembersDesc, prometheus.GaugeValue,
+				float64(state.desiredMembers), ns, name)
+			ch <- prometheus.MustNewConstMetric(readyMembersDesc, prometheus.GaugeValue,
+				float64(state.readyMembers), ns, name)
+		}
+		for node, nodeState := range state.nodes {
+			ch <- prometheus.MustNewConstMetric(nodeConfigAppliedDesc, prometheus.GaugeValue,
+				boolToFloat(nodeState.applied), ns, name, node)
+			ch <- prometheus.MustNewConstMetric(nodeExternalIDsDriftDesc, prometheus.GaugeValue,
+				float64(nodeState.drifted), ns, name, node)
+		}
+	}
+}
+
+func (c *collector) state(kind string, name types.NamespacedName) *resourceState {
+	key := resourceKey{kind: kind, name: name}
+	state, ok := c.resources[key]
+	if !ok {
+		state = &resourceState{nodes: map[string]nodeState{}}
+		c.resources[key] = state
+	}
+	return state
+}
+
+// ObserveReconcile - records the conditions of the resource at the end of a
+// reconcile. reconcileErr is the error returned by the reconcile.
+func ObserveReconcile(
+	kind string,
+	name types.NamespacedName,
+	conditions condition.Conditions,
+	reconcileErr error,
+) {
+	stateCollector.mu.Lock()
+	defer stateCollector.mu.Unlock()
+
+	state := stateCollector.state(kind, name)
+	state.ready = conditions.IsTrue(condition.ReadyCondition)
+	state.conditions = conditions.DeepCopy()
+	if reconcileErr == nil {
+		state.lastSuccess = time.Now()
+	}
+}
+
+// ObserveDBMembers - records the desired and ready members of an OVNDBCluster
+func ObserveDBMembers(kind string, name types.NamespacedName, desired int32, ready int32) {
+	stateCollector.mu.Lock()
+	defer stateCollector.mu.Unlock()
+
+	state := stateCollector.state(kind, name)
+	state.members = true
+	state.desiredMembers = desired
+	state.readyMembers = ready
+}
+
+// ObserveNodeConfig - records whether the config agent of the ovs pod on node
+// applied the current node configuration and the number of external-ids it
+// reports as drifted
+func ObserveNodeConfig(
+	kind string,
+	name types.NamespacedName,
+	node string,
+	applied bool,
+	drifted int,
+) {
+	stateCollector.mu.Lock()
+	defer stateCollector.mu.Unlock()
+
+	stateCollector.state(kind, name).nodes[node] = nodeState{applied: applied, drifted: drifted}
+}
+
+// ForgetNodes - drops the node configuration of nodes which are not in nodes
+func ForgetNodes(kind string, name types.NamespacedName, nodes []string) {
+	stateCollector.mu.Lock()
+	defer stateCollector.mu.Unlock()
+
+	state := stateCollector.state(kind, name)
+	current := map[string]bool{}
+	for _, node := range nodes {
+		current[node] = true
+	}
+	for node := range state.nodes {
+		if !current[node] {
+			delete(state.nodes, node)
+		}
+	}
+}
+
+// Forget - drops all recorded state of a deleted resource
+func Forget(kind string, name types.NamespacedName) {
+	stateCollector.mu.Lock()
+	defer stateCollector.mu.Unlock()
+
+	delete(stateCollector.resources, resourceKey{kind: kind, name: name})
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -1 +1,24 @@
 package ovncontroller
+
+import (
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+)
+
+// VersionProbes - queries the ovn-controller, ovsdb-server and ovs-vswitchd versions
+var VersionProbes = []ovn_common.VersionProbe{
+	{
+		Component: ovn_common.ComponentOVNController,
+		Container: "ovn-controller",
+		Command:   []string{"ovn-controller", "--version"},
+	},
+	{
+		Component: ovn_common.ComponentOVSDBServer,
+		Container: "ovsdb-server",
+		Command:   []string{"ovsdb-server", "--version"},
+	},
+	{
+		Component: ovn_common.ComponentOVSVswitchd,
+		Container: "ovs-vswitchd",
+		Command:   []string{"ovs-vswitchd", "--version"},
+	},
+}
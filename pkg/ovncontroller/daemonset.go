@@ -31,7 +31,7 @@ func CreateOVNDaemonSet(
 	configHash string,
 	labels map[string]string,
 ) *appsv1.DaemonSet {
-	volumes := GetOVNControllerVolumes(instance.Name, instance.Namespace)
+	volumes := GetOVNControllerVolumes(instance.Name, GetHostPath(instance))
 	mounts := GetOVNControllerVolumeMounts()
 
 	cmd := []string{
@@ -118,15 +118,15 @@ func CreateOVNDaemonSet(
 			VolumeMounts:   mounts,
 			ReadinessProbe: ovnControllerReadinessProbe,
 			LivenessProbe:  ovnControllerLivenessProbe,
-			// TODO: consider the fact that resources are now double booked
-			Resources:                instance.Spec.Resources,
+			// the shared Resources are double booked by containers without their own
+			Resources:                ovn_common.ContainerResources(instance.Spec.ContainerResources.OVNController, instance.Spec.Resources),
 			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		},
 	}
 
 	daemonset := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      ovnv1.ServiceNameOVNController,
+			Name:      instance.ScopedName(ovnv1.ServiceNameOVNController),
 			Namespace: instance.Namespace,
 		},
 		Spec: appsv1.DaemonSetSpec{
@@ -149,12 +149,15 @@ func CreateOVNDaemonSet(
 	if instance.Spec.NodeSelector != nil {
 		daemonset.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
 	}
+	daemonset.Spec.Template.Spec.Tolerations = instance.Spec.Tolerations
+	daemonset.Spec.Template.Spec.PriorityClassName = instance.Spec.PriorityClassName
 
 	return daemonset
 }
 
 func CreateOVSDaemonSet(
 	instance *ovnv1.OVNController,
+	group NodeGroup,
 	configHash string,
 	labels map[string]string,
 	annotations map[string]string,
@@ -259,8 +262,8 @@ func CreateOVSDaemonSet(
 			},
 			Env:          env.MergeEnvs([]corev1.EnvVar{}, envVars),
 			VolumeMounts: GetOVSDbVolumeMounts(),
-			// TODO: consider the fact that resources are now double booked
-			Resources:                instance.Spec.Resources,
+			// the shared Resources are double booked by containers without their own
+			Resources:                ovn_common.ContainerResources(instance.Spec.ContainerResources.OVSDBServer, instance.Spec.Resources),
 			LivenessProbe:            ovsDbLivenessProbe,
 			ReadinessProbe:           ovsDbReadinessProbe,
 			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
@@ -286,18 +289,20 @@ func CreateOVSDaemonSet(
 			},
 			Env:          env.MergeEnvs([]corev1.EnvVar{}, envVars),
 			VolumeMounts: GetVswitchdVolumeMounts(),
-			// TODO: consider the fact that resources are now double booked
-			Resources:                instance.Spec.Resources,
+			// the shared Resources are double booked by containers without their own
+			Resources:                ovn_common.ContainerResources(instance.Spec.ContainerResources.OVSVswitchd, instance.Spec.Resources),
 			LivenessProbe:            ovsVswitchdLivenessProbe,
 			ReadinessProbe:           ovsVswitchdReadinessProbe,
 			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		},
+		ConfigAgentContainer(instance),
 	}
 
 	daemonset := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      ovnv1.ServiceNameOVS,
+			Name:      group.OVSDaemonSetName(),
 			Namespace: instance.Namespace,
+			Labels:    labels,
 		},
 		Spec: appsv1.DaemonSetSpec{
 			Selector: &metav1.LabelSelector{
@@ -311,15 +316,19 @@ func CreateOVSDaemonSet(
 					ServiceAccountName: instance.RbacResourceName(),
 					InitContainers:     initContainers,
 					Containers:         containers,
-					Volumes:            GetOVSVolumes(instance.Name, instance.Namespace),
+					Volumes: append(GetOVSVolumes(instance.Name, GetHostPath(instance)),
+						NodeConfigVolume(instance, group)),
 				},
 			},
 		},
 	}
 
-	if instance.Spec.NodeSelector != nil {
-		daemonset.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
+	if group.NodeSelector != nil {
+		daemonset.Spec.Template.Spec.NodeSelector = group.NodeSelector
 	}
+	daemonset.Spec.Template.Spec.Affinity = group.Affinity
+	daemonset.Spec.Template.Spec.Tolerations = instance.Spec.Tolerations
+	daemonset.Spec.Template.Spec.PriorityClassName = instance.Spec.PriorityClassName
 
 	if len(annotations) > 0 {
 		daemonset.Spec.Template.ObjectMeta.Annotations = annotations
@@ -14,6 +14,7 @@ package ovncontroller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	netattdefv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
@@ -25,18 +26,20 @@ import (
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 )
 
-// CreateOrUpdateAdditionalNetworks - create or update network attachment definitions based on the provided mappings
+// CreateOrUpdateAdditionalNetworks - create or update network attachment definitions based on the mappings of the node group
 func CreateOrUpdateAdditionalNetworks(
 	ctx context.Context,
 	h *helper.Helper,
 	instance *ovnv1.OVNController,
+	group NodeGroup,
 	labels map[string]string,
 ) ([]string, error) {
 
 	var nad *netattdefv1.NetworkAttachmentDefinition
 	var networkAttachments []string
 
-	for physNet, interfaceName := range instance.Spec.NicMappings {
+	for physNet, interfaceName := range group.NicMappings {
+		nadName := group.NADName(physNet)
 		nadSpec := netattdefv1.NetworkAttachmentDefinitionSpec{
 			Config: fmt.Sprintf(
 				`{"cniVersion": "0.3.1", "name": "%s", "type": "host-device", "device": "%s"}`,
@@ -47,20 +50,20 @@ func CreateOrUpdateAdditionalNetworks(
 			ctx,
 			client.ObjectKey{
 				Namespace: instance.Namespace,
-				Name:      physNet,
+				Name:      nadName,
 			},
 			nad,
 		)
 		if err != nil {
 			if !k8s_errors.IsNotFound(err) {
 				return nil, fmt.Errorf("cannot get NetworkAttachmentDefinition %s/%s: %w",
-					physNet, interfaceName, err)
+					nadName, interfaceName, err)
 			}
 
 			ownerRef := metav1.NewControllerRef(instance, instance.GroupVersionKind())
 			nad = &netattdefv1.NetworkAttachmentDefinition{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:            physNet,
+					Name:            nadName,
 					Namespace:       instance.Namespace,
 					Labels:          labels,
 					OwnerReferences: []metav1.OwnerReference{*ownerRef},
@@ -70,7 +73,7 @@ func CreateOrUpdateAdditionalNetworks(
 			// Request object not found, lets create it
 			if err := h.GetClient().Create(ctx, nad); err != nil {
 				return nil, fmt.Errorf("cannot create NetworkAttachmentDefinition %s/%s: %w",
-					physNet, interfaceName, err)
+					nadName, interfaceName, err)
 			}
 		} else {
 			owned := false
@@ -84,13 +87,36 @@ func CreateOrUpdateAdditionalNetworks(
 				nad.Spec = nadSpec
 				if err := h.GetClient().Update(ctx, nad); err != nil {
 					return nil, fmt.Errorf("cannot update NetworkAttachmentDefinition %s/%s: %w",
-						physNet, interfaceName, err)
+						nadName, interfaceName, err)
 				}
 			}
 		}
 
-		networkAttachments = append(networkAttachments, physNet)
+		networkAttachments = append(networkAttachments, nadName)
 	}
 
 	return networkAttachments, nil
 }
+
+// SetPhysnetInterfaceNames - the physnet NICs are named after the physnet in
+// the ovs pods, also if the NetworkAttachmentDefinition of a node group has a
+// different name
+func SetPhysnetInterfaceNames(annotations map[string]string, group NodeGroup) error {
+	networks := []netattdefv1.NetworkSelectionElement{}
+	if err := json.Unmarshal([]byte(annotations[netattdefv1.NetworkAttachmentAnnot]), &networks); err != nil {
+		return fmt.Errorf("failed to decode networks annotation: %w", err)
+	}
+	for physNet := range group.NicMappings {
+		for i := range networks {
+			if networks[i].Name == group.NADName(physNet) {
+				networks[i].InterfaceRequest = physNet
+			}
+		}
+	}
+	data, err := json.Marshal(networks)
+	if err != nil {
+		return fmt.Errorf("failed to encode networks %v: %w", networks, err)
+	}
+	annotations[netattdefv1.NetworkAttachmentAnnot] = string(data)
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ConfigHashAnnotation - annotation of the ovs pods with the hash of the
+	// node configuration the config agent applied
+	ConfigHashAnnotation = "ovn.openstack.org/config-hash"
+
+	// nodeConfigPath - directory of the node configuration in the config agent
+	nodeConfigPath = "/var/lib/ovn-config"
+	// nodeConfigFile - file of the node configuration of the group
+	nodeConfigFile = "config"
+)
+
+// NodeConfigMapName - name of the ConfigMap with the node configuration of the
+// node groups, which the config agents of the ovs pods apply
+func NodeConfigMapName(instance *ovnv1.OVNController) string {
+	return instance.Name + "-node-config"
+}
+
+// NodeConfigKey - key of the node configuration of the group in the ConfigMap
+func (g NodeGroup) NodeConfigKey() string {
+	return g.Name
+}
+
+// NodeConfig - renders the node configuration of the group as KEY=VALUE
+// lines, the last line is the ConfigHash of the settings before it. The drift
+// settings and the extra OVS keys are only rendered if they differ from the
+// defaults, so the hash of the configuration does not change for
+// OVNControllers which don't use them.
+func NodeConfig(instance *ovnv1.OVNController, group NodeGroup, sbEndpoint string) (string, string, error) {
+	settings := map[string]string{
+		"OVNBridge":              group.ExternalIDS.OvnBridge,
+		"OVNRemote":              sbEndpoint,
+		"OVNEncapType":           group.ExternalIDS.OvnEncapType,
+		"OVNAvailabilityZones":   strings.Join(group.ExternalIDS.OvnAvailabilityZones, ":"),
+		"EnableChassisAsGateway": "true",
+		"OVNRemoteProbeInterval": "",
+		"PhysicalNetworks":       getPhysicalNetworks(group.NicMappings),
+	}
+	if group.ExternalIDS.EnableChassisAsGateway != nil {
+		settings["EnableChassisAsGateway"] = fmt.Sprintf("%t", *group.ExternalIDS.EnableChassisAsGateway)
+	}
+	if group.ExternalIDS.OvnRemoteProbeInterval != nil {
+		settings["OVNRemoteProbeInterval"] = fmt.Sprintf("%d", *group.ExternalIDS.OvnRemoteProbeInterval)
+	}
+	if len(instance.Spec.ExtraExternalIDs) > 0 {
+		settings["ExtraExternalIDs"] = keyValuePairs(instance.Spec.ExtraExternalIDs)
+	}
+	if len(instance.Spec.OVSOtherConfig) > 0 {
+		settings["OVSOtherConfig"] = keyValuePairs(instance.Spec.OVSOtherConfig)
+	}
+	if instance.Spec.ExternalIDsDriftRemediation == ovnv1.DriftRemediationManual {
+		settings["DriftRemediation"] = ovnv1.DriftRemediationManual
+	}
+	// a new request changes the hash, which makes every agent re-apply it
+	if request := instance.Annotations[ovnv1.RemediateDriftAnnotation]; request != "" {
+		settings["RemediationRequest"] = request
+	}
+
+	hash, err := util.ObjectHash(settings)
+	if err != nil {
+		return "", "", err
+	}
+
+	keys := maps.Keys(settings)
+	sort.Strings(keys)
+	var config strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&config, "%s=%s\n", key, settings[key])
+	}
+	fmt.Fprintf(&config, "ConfigHash=%s\n", hash)
+
+	return config.String(), hash, nil
+}
+
+// ConfigAgentContainer - the container of the ovs pods which applies the
+// node configuration of the group whenever it changes and reports the hash of
+// the applied configuration in the ConfigHashAnnotation of the pod. Between
+// changes it compares the external-ids with the configuration and reports or
+// re-applies the ones which drifted.
+func ConfigAgentContainer(instance *ovnv1.OVNController) corev1.Container {
+	runAsUser := int64(0)
+	privileged := true
+
+	mounts := append(GetVswitchdVolumeMounts(), corev1.VolumeMount{
+		Name:      "node-config",
+		MountPath: nodeConfigPath,
+		ReadOnly:  true,
+	})
+
+	env := []corev1.EnvVar{
+		{Name: "NodeConfig", Value: nodeConfigPath + "/" + nodeConfigFile},
+		fieldRefEnvVar("OVNHostName", "spec.nodeName"),
+		fieldRefEnvVar("PodName", "metadata.name"),
+		fieldRefEnvVar("PodNamespace", "metadata.namespace"),
+	}
+	// the agent reports the state of the node in these annotations
+	names := maps.Keys(agentAnnotations)
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, corev1.EnvVar{Name: name, Value: agentAnnotations[name]})
+	}
+
+	return corev1.Container{
+		Name:    "ovn-config",
+		Command: []string{"/usr/local/bin/container-scripts/ovn-config-agent.sh"},
+		// the ovs image keeps the ovs pods from restarting on ovn-controller
+		// updates, it ships the ovs-vsctl the agent needs
+		Image: instance.Spec.OvsContainerImage,
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:  &runAsUser,
+			Privileged: &privileged,
+		},
+		Env:                      env,
+		VolumeMounts:             mounts,
+		Resources:                ovn_common.ContainerResources(instance.Spec.ContainerResources.ConfigAgent, instance.Spec.Resources),
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+}
+
+// NodeConfigVolume - the node configuration of the group, the ConfigMap is
+// optional as it is only rendered once the SB database is available
+func NodeConfigVolume(instance *ovnv1.OVNController, group NodeGroup) corev1.Volume {
+	optional := true
+	return corev1.Volume{
+		Name: "node-config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: NodeConfigMapName(instance),
+				},
+				Items: []corev1.KeyToPath{
+					{Key: group.NodeConfigKey(), Path: nodeConfigFile},
+				},
+				Optional: &optional,
+			},
+		},
+	}
+}
+
+// NodeConfigApplied - returns whether the config agent of the ovs pod applied
+// the configuration with the given hash
+func NodeConfigApplied(pod corev1.Pod, hash string) bool {
+	return hash != "" && pod.Annotations[ConfigHashAnnotation] == hash
+}
+
+// keyValuePairs - renders the map as space separated KEY=VALUE pairs sorted by
+// key, the values are validated not to contain whitespace
+func keyValuePairs(m map[string]string) string {
+	keys := maps.Keys(m)
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+m[key])
+	}
+	return strings.Join(pairs, " ")
+}
+
+func fieldRefEnvVar(name string, fieldPath string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: fieldPath},
+		},
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"sort"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NodeGroupLabel - label of the ovs pods and DaemonSets of a node group
+const NodeGroupLabel = ovnv1.NodeGroupLabel
+
+// NodeGroup - the settings of the nodes of a node group, with the overrides
+// of the group applied on top of the spec
+type NodeGroup struct {
+	Name         string
+	NicMappings  map[string]string
+	ExternalIDS  ovnv1.OVSExternalIDs
+	NodeSelector map[string]string
+	// Affinity - excludes the nodes of the groups before this one
+	Affinity *corev1.Affinity
+	// ownNADs - the group has its own nicMappings and NetworkAttachmentDefinitions
+	ownNADs bool
+	// ovsServiceName - the ovs service name scoped to the OVNController
+	ovsServiceName string
+	// nadPrefix - prefix of the NetworkAttachmentDefinitions of the OVNController
+	nadPrefix string
+}
+
+// GetNodeGroups - returns the node groups of the spec, preceded by the default
+// group of the nodes which are not part of any of them
+func GetNodeGroups(instance *ovnv1.OVNController) []NodeGroup {
+	var nodeSelector map[string]string
+	if instance.Spec.NodeSelector != nil {
+		nodeSelector = *instance.Spec.NodeSelector
+	}
+
+	ovsServiceName := instance.ScopedName(ovnv1.ServiceNameOVS)
+	nadPrefix := ""
+	if instance.Name != ovnv1.OVNControllerLegacyName {
+		nadPrefix = instance.Name + "-"
+	}
+
+	groups := []NodeGroup{{
+		Name:           ovnv1.NodeGroupDefault,
+		NicMappings:    instance.Spec.NicMappings,
+		ExternalIDS:    instance.Spec.ExternalIDS,
+		NodeSelector:   nodeSelector,
+		Affinity:       excludeNodeSelectors(instance.Spec.NodeGroups),
+		ovsServiceName: ovsServiceName,
+		nadPrefix:      nadPrefix,
+	}}
+
+	for i, spec := range instance.Spec.NodeGroups {
+		group := NodeGroup{
+			Name:           spec.Name,
+			NicMappings:    instance.Spec.NicMappings,
+			ExternalIDS:    *instance.Spec.ExternalIDS.DeepCopy(),
+			NodeSelector:   map[string]string{},
+			Affinity:       excludeNodeSelectors(instance.Spec.NodeGroups[:i]),
+			ovsServiceName: ovsServiceName,
+			nadPrefix:      nadPrefix,
+		}
+		maps.Copy(group.NodeSelector, nodeSelector)
+		maps.Copy(group.NodeSelector, spec.NodeSelector)
+		if spec.NicMappings != nil {
+			group.NicMappings = *spec.NicMappings
+			group.ownNADs = true
+		}
+		if ids := spec.ExternalIDS; ids != nil {
+			if ids.OvnEncapType != "" {
+				group.ExternalIDS.OvnEncapType = ids.OvnEncapType
+			}
+			if ids.OvnAvailabilityZones != nil {
+				group.ExternalIDS.OvnAvailabilityZones = ids.OvnAvailabilityZones
+			}
+			if ids.EnableChassisAsGateway != nil {
+				group.ExternalIDS.EnableChassisAsGateway = ids.EnableChassisAsGateway
+			}
+			if ids.OvnRemoteProbeInterval != nil {
+				group.ExternalIDS.OvnRemoteProbeInterval = ids.OvnRemoteProbeInterval
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// GetNodeGroup - returns the node group with the given name, the default group
+// if there is none
+func GetNodeGroup(groups []NodeGroup, name string) NodeGroup {
+	for _, group := range groups {
+		if group.Name == name {
+			return group
+		}
+	}
+	return groups[0]
+}
+
+// IsDefault - returns true for the group of the nodes without a node group
+func (g NodeGroup) IsDefault() bool {
+	return g.Name == ovnv1.NodeGroupDefault
+}
+
+// OVSDaemonSetName - name of the ovs DaemonSet of the group. The default group
+// keeps the name used before node groups existed.
+func (g NodeGroup) OVSDaemonSetName() string {
+	if g.IsDefault() {
+		return g.ovsServiceName
+	}
+	return g.ovsServiceName + "-" + g.Name
+}
+
+// Labels - labels of the ovs DaemonSet and pods of the group
+func (g NodeGroup) Labels(serviceLabels map[string]string) map[string]string {
+	labels := maps.Clone(serviceLabels)
+	if !g.IsDefault() {
+		labels[NodeGroupLabel] = g.Name
+	}
+	return labels
+}
+
+// NADName - name of the NetworkAttachmentDefinition of a physnet. Groups with
+// their own nicMappings need their own definitions as the NICs differ, as do
+// the other OVNControllers of the namespace.
+func (g NodeGroup) NADName(physnet string) string {
+	if g.ownNADs {
+		return g.nadPrefix + physnet + "-" + g.Name
+	}
+	return g.nadPrefix + physnet
+}
+
+// excludeNodeSelectors - returns the node affinity which excludes the nodes
+// matching any of the node groups. A node is excluded from a group if any of
+// its labels does not match, so the terms are the combinations of one
+// mismatching label per group.
+func excludeNodeSelectors(groups []ovnv1.OVNControllerNodeGroup) *corev1.Affinity {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	terms := [][]corev1.NodeSelectorRequirement{{}}
+	for _, group := range groups {
+		keys := maps.Keys(group.NodeSelector)
+		sort.Strings(keys)
+		next := [][]corev1.NodeSelectorRequirement{}
+		for _, term := range terms {
+			for _, key := range keys {
+				next = append(next, append(append([]corev1.NodeSelectorRequirement{}, term...),
+					corev1.NodeSelectorRequirement{
+						Key:      key,
+						Operator: corev1.NodeSelectorOpNotIn,
+						Values:   []string{group.NodeSelector[key]},
+					}))
+			}
+		}
+		terms = next
+	}
+
+	nodeSelectorTerms := []corev1.NodeSelectorTerm{}
+	for _, term := range terms {
+		nodeSelectorTerms = append(nodeSelectorTerms, corev1.NodeSelectorTerm{MatchExpressions: term})
+	}
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: nodeSelectorTerms,
+			},
+		},
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovncontroller
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConfigAppliedAnnotation - annotation of the ovs pods with the time the
+	// config agent applied the node configuration
+	ConfigAppliedAnnotation = "ovn.openstack.org/config-applied"
+	// ConfigErrorAnnotation - annotation of the ovs pods with the last error
+	// of the config agent applying the node configuration
+	ConfigErrorAnnotation = "ovn.openstack.org/config-error"
+	// DriftAnnotation - annotation of the ovs pods with the comma separated
+	// keys of the external-ids which differ from the applied configuration
+	DriftAnnotation = "ovn.openstack.org/external-ids-drift"
+	// RemediatedAnnotation - annotation of the ovs pods with the time the
+	// config agent last re-applied drifted external-ids
+	RemediatedAnnotation = "ovn.openstack.org/external-ids-remediated"
+	// SystemIDAnnotation - annotation of the ovs pods with the system-id of
+	// the chassis
+	SystemIDAnnotation = "ovn.openstack.org/system-id"
+	// EncapIPAnnotation - annotation of the ovs pods with the ovn-encap-ip of
+	// the chassis
+	EncapIPAnnotation = "ovn.openstack.org/encap-ip"
+	// BridgeMappingsAnnotation - annotation of the ovs pods with the
+	// ovn-bridge-mappings in place on the node
+	BridgeMappingsAnnotation = "ovn.openstack.org/bridge-mappings"
+)
+
+// agentAnnotations - environment variables of the config agent with the
+// names of the annotations it sets
+var agentAnnotations = map[string]string{
+	"ConfigHashAnnotation":     ConfigHashAnnotation,
+	"ConfigAppliedAnnotation":  ConfigAppliedAnnotation,
+	"ConfigErrorAnnotation":    ConfigErrorAnnotation,
+	"DriftAnnotation":          DriftAnnotation,
+	"RemediatedAnnotation":     RemediatedAnnotation,
+	"SystemIDAnnotation":       SystemIDAnnotation,
+	"EncapIPAnnotation":        EncapIPAnnotation,
+	"BridgeMappingsAnnotation": BridgeMappingsAnnotation,
+}
+
+// NodeStatuses - returns the state of each node running an ovn-controller or
+// ovs pod of the instance, sorted by node. The state of the chassis is read
+// from the annotations the config agent sets on the ovs pod, the connection to
+// the SB database from the readiness of ovn-controller.
+func NodeStatuses(
+	instance *ovnv1.OVNController,
+	configHashes map[string]string,
+	pods []corev1.Pod,
+) []ovnv1.OVNControllerNodeStatus {
+	groupByNode := NodeGroupsByNode(instance, pods)
+	nodes := map[string]*ovnv1.OVNControllerNodeStatus{}
+	node := func(name string) *ovnv1.OVNControllerNodeStatus {
+		if _, ok := nodes[name]; !ok {
+			nodes[name] = &ovnv1.OVNControllerNodeStatus{Node: name, NodeGroup: groupByNode[name]}
+		}
+		return nodes[name]
+	}
+
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		switch pod.Labels["service"] {
+		case instance.ScopedName(ovnv1.ServiceNameOVNController):
+			// the readiness probe checks the connection to the SB database
+			node(pod.Spec.NodeName).OVNControllerConnected = containerReady(pod, "ovn-controller")
+		case instance.ScopedName(ovnv1.ServiceNameOVS):
+			status := node(pod.Spec.NodeName)
+			status.OVSReady = containerReady(pod, "ovs-vswitchd")
+			status.SystemID = pod.Annotations[SystemIDAnnotation]
+			status.EncapIP = pod.Annotations[EncapIPAnnotation]
+			status.BridgeMappings = splitAnnotation(pod, BridgeMappingsAnnotation)
+			status.ConfigHash = pod.Annotations[ConfigHashAnnotation]
+			status.ConfigUpToDate = NodeConfigApplied(pod, configHashes[status.NodeGroup])
+			status.ConfigAppliedTime = timeAnnotation(pod, ConfigAppliedAnnotation)
+			status.ConfigError = pod.Annotations[ConfigErrorAnnotation]
+			status.ExternalIDsDrift = splitAnnotation(pod, DriftAnnotation)
+			status.LastRemediationTime = timeAnnotation(pod, RemediatedAnnotation)
+		}
+	}
+
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	status := make([]ovnv1.OVNControllerNodeStatus, 0, len(names))
+	for _, name := range names {
+		status = append(status, *nodes[name])
+	}
+	return status
+}
+
+// containerReady - returns whether the container of the pod is ready
+func containerReady(pod corev1.Pod, container string) bool {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == container {
+			return cs.Ready
+		}
+	}
+	return false
+}
+
+// splitAnnotation - returns the comma separated values of the annotation
+func splitAnnotation(pod corev1.Pod, annotation string) []string {
+	if value := pod.Annotations[annotation]; value != "" {
+		return strings.Split(value, ",")
+	}
+	return nil
+}
+
+// timeAnnotation - returns the RFC3339 time of the annotation, nil if it is
+// unset or invalid
+func timeAnnotation(pod corev1.Pod, annotation string) *metav1.Time {
+	t, err := time.Parse(time.RFC3339, pod.Annotations[annotation])
+	if err != nil {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
@@ -25,16 +25,16 @@ import (
 )
 
 func getPhysicalNetworks(
-	instance *ovnv1.OVNController,
+	nicMappings map[string]string,
 ) string {
 	// NOTE(slaweq): to make things easier, each physical bridge will have
 	//               the same name as "br-<physical network>"
 	// NOTE(slaweq): interface names aren't important as inside Pod they will be
 	//               named based on the NicMappings keys
 	// Need to pass sorted data as Map is unordered
-	nicMappings := maps.Keys(instance.Spec.NicMappings)
-	sort.Strings(nicMappings)
-	return strings.Join(nicMappings, " ")
+	physNets := maps.Keys(nicMappings)
+	sort.Strings(physNets)
+	return strings.Join(physNets, " ")
 }
 
 func getOVNControllerPods(
@@ -48,7 +48,7 @@ func getOVNControllerPods(
 		Namespace: instance.Namespace,
 	}
 	client.MatchingLabels{
-		"service": ovnv1.ServiceNameOVNController,
+		"service": instance.ScopedName(ovnv1.ServiceNameOVNController),
 	}.ApplyToList(podListOpts)
 
 	if err := k8sClient.List(ctx, podList, podListOpts); err != nil {
@@ -58,3 +58,42 @@ func getOVNControllerPods(
 
 	return podList, nil
 }
+
+// getNodeGroupsByNode - returns the node group of each node running an ovs pod
+func getNodeGroupsByNode(
+	ctx context.Context,
+	k8sClient client.Client,
+	instance *ovnv1.OVNController,
+) (map[string]string, error) {
+
+	podList := &corev1.PodList{}
+	podListOpts := &client.ListOptions{
+		Namespace: instance.Namespace,
+	}
+	client.MatchingLabels{
+		"service": instance.ScopedName(ovnv1.ServiceNameOVS),
+	}.ApplyToList(podListOpts)
+
+	if err := k8sClient.List(ctx, podList, podListOpts); err != nil {
+		err = fmt.Errorf("error listing ovs pods for instance %s: %w", instance.Name, err)
+		return nil, err
+	}
+
+	return NodeGroupsByNode(instance, podList.Items), nil
+}
+
+// NodeGroupsByNode - returns the node group of each node running one of the
+// given ovs pods of the instance, the pods of the default group are not labeled
+func NodeGroupsByNode(instance *ovnv1.OVNController, pods []corev1.Pod) map[string]string {
+	nodeGroups := map[string]string{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.Labels["service"] != instance.ScopedName(ovnv1.ServiceNameOVS) {
+			continue
+		}
+		nodeGroups[pod.Spec.NodeName] = ovnv1.NodeGroupDefault
+		if name, ok := pod.Labels[NodeGroupLabel]; ok {
+			nodeGroups[pod.Spec.NodeName] = name
+		}
+	}
+	return nodeGroups
+}
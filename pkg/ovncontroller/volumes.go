@@ -3,10 +3,21 @@ package ovncontroller
 import (
 	"fmt"
 
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 )
 
-func GetOVNControllerVolumes(name string, namespace string) []corev1.Volume {
+// GetHostPath - returns the host directory of the OVS and OVN state of the
+// instance, the OVNControllerLegacyName CR keeps the directory of the namespace
+func GetHostPath(instance *ovnv1.OVNController) string {
+	hostPath := fmt.Sprintf("/var/home/core/%s", instance.Namespace)
+	if instance.Name != ovnv1.OVNControllerLegacyName {
+		hostPath = fmt.Sprintf("%s/%s", hostPath, instance.Name)
+	}
+	return hostPath
+}
+
+func GetOVNControllerVolumes(name string, hostPath string) []corev1.Volume {
 
 	var scriptsVolumeDefaultMode int32 = 0755
 	directoryOrCreate := corev1.HostPathDirectoryOrCreate
@@ -17,7 +28,7 @@ func GetOVNControllerVolumes(name string, namespace string) []corev1.Volume {
 			Name: "etc-ovs",
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: fmt.Sprintf("/var/home/core/%s/etc/ovs", namespace),
+					Path: hostPath + "/etc/ovs",
 					Type: &directoryOrCreate,
 				},
 			},
@@ -26,7 +37,7 @@ func GetOVNControllerVolumes(name string, namespace string) []corev1.Volume {
 			Name: "var-run",
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: fmt.Sprintf("/var/home/core/%s/var/run/openvswitch", namespace),
+					Path: hostPath + "/var/run/openvswitch",
 					Type: &directoryOrCreate,
 				},
 			},
@@ -35,7 +46,7 @@ func GetOVNControllerVolumes(name string, namespace string) []corev1.Volume {
 			Name: "var-log",
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: fmt.Sprintf("/var/home/core/%s/var/log/openvswitch", namespace),
+					Path: hostPath + "/var/log/openvswitch",
 					Type: &directoryOrCreate,
 				},
 			},
@@ -44,7 +55,7 @@ func GetOVNControllerVolumes(name string, namespace string) []corev1.Volume {
 			Name: "var-lib",
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: fmt.Sprintf("/var/home/core/%s/var/lib/openvswitch", namespace),
+					Path: hostPath + "/var/lib/openvswitch",
 					Type: &directoryOrCreate,
 				},
 			},
@@ -53,7 +64,7 @@ func GetOVNControllerVolumes(name string, namespace string) []corev1.Volume {
 			Name: "var-run-ovn",
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: fmt.Sprintf("/var/home/core/%s/var/run/ovn", namespace),
+					Path: hostPath + "/var/run/ovn",
 					Type: &directoryOrCreate,
 				},
 			},
@@ -62,7 +73,7 @@ func GetOVNControllerVolumes(name string, namespace string) []corev1.Volume {
 			Name: "var-log-ovn",
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: fmt.Sprintf("/var/home/core/%s/var/log/ovn", namespace),
+					Path: hostPath + "/var/log/ovn",
 					Type: &directoryOrCreate,
 				},
 			},
@@ -82,7 +93,7 @@ func GetOVNControllerVolumes(name string, namespace string) []corev1.Volume {
 
 }
 
-func GetOVSVolumes(name string, namespace string) []corev1.Volume {
+func GetOVSVolumes(name string, hostPath string) []corev1.Volume {
 
 	var scriptsVolumeDefaultMode int32 = 0755
 	directoryOrCreate := corev1.HostPathDirectoryOrCreate
@@ -93,7 +104,7 @@ func GetOVSVolumes(name string, namespace string) []corev1.Volume {
 			Name: "etc-ovs",
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: fmt.Sprintf("/var/home/core/%s/etc/ovs", namespace),
+					Path: hostPath + "/etc/ovs",
 					Type: &directoryOrCreate,
 				},
 			},
@@ -102,7 +113,7 @@ func GetOVSVolumes(name string, namespace string) []corev1.Volume {
 			Name: "var-run",
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: fmt.Sprintf("/var/home/core/%s/var/run/openvswitch", namespace),
+					Path: hostPath + "/var/run/openvswitch",
 					Type: &directoryOrCreate,
 				},
 			},
@@ -111,7 +122,7 @@ func GetOVSVolumes(name string, namespace string) []corev1.Volume {
 			Name: "var-log",
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: fmt.Sprintf("/var/home/core/%s/var/log/openvswitch", namespace),
+					Path: hostPath + "/var/log/openvswitch",
 					Type: &directoryOrCreate,
 				},
 			},
@@ -120,7 +131,7 @@ func GetOVSVolumes(name string, namespace string) []corev1.Volume {
 			Name: "var-lib",
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: fmt.Sprintf("/var/home/core/%s/var/lib/openvswitch", namespace),
+					Path: hostPath + "/var/lib/openvswitch",
 					Type: &directoryOrCreate,
 				},
 			},
@@ -0,0 +1,57 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"fmt"
+	"reflect"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovsdb"
+	"k8s.io/utils/ptr"
+)
+
+// dscpKey - other_config key of the DSCP value of a connection
+const dscpKey = "dscp"
+
+// RemoteConnection - returns the connection with the settings of the spec
+// applied. The connections themselves are created by setup.sh, which knows
+// the address family of the pods, the other columns are kept.
+func RemoteConnection(instance *ovnv1.OVNDBCluster, current ovsdb.Connection) ovsdb.Connection {
+	conn := current
+
+	// 0 disables the inactivity probe
+	conn.InactivityProbe = ptr.To(int(instance.Spec.GetInactivityProbe()))
+	conn.MaxBackoff = nil
+	if instance.Spec.ExtraArgs.Remote.MaxBackoff != nil {
+		conn.MaxBackoff = ptr.To(int(*instance.Spec.ExtraArgs.Remote.MaxBackoff))
+	}
+
+	conn.OtherConfig = map[string]string{}
+	for key, value := range current.OtherConfig {
+		if key != dscpKey {
+			conn.OtherConfig[key] = value
+		}
+	}
+	if instance.Spec.ExtraArgs.Remote.Dscp != nil {
+		conn.OtherConfig[dscpKey] = fmt.Sprintf("%d", *instance.Spec.ExtraArgs.Remote.Dscp)
+	}
+	return conn
+}
+
+// ConnectionChanged - whether the settings of the connection differ
+func ConnectionChanged(current ovsdb.Connection, desired ovsdb.Connection) bool {
+	return !reflect.DeepEqual(current.InactivityProbe, desired.InactivityProbe) ||
+		!reflect.DeepEqual(current.MaxBackoff, desired.MaxBackoff) ||
+		current.OtherConfig[dscpKey] != desired.OtherConfig[dscpKey]
+}
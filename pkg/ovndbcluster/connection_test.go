@@ -0,0 +1,62 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovsdb"
+	"k8s.io/utils/ptr"
+)
+
+func TestRemoteConnection(t *testing.T) {
+	g := NewWithT(t)
+
+	instance := &ovnv1.OVNDBCluster{}
+	instance.Spec.InactivityProbe = ptr.To[int32](60000)
+	instance.Spec.ExtraArgs.Remote.Dscp = ptr.To[int32](46)
+	instance.Spec.ExtraArgs.Remote.MaxBackoff = ptr.To[int32](8000)
+
+	// a connection created by set-connection
+	current := ovsdb.Connection{
+		UUID:        "conn",
+		Target:      "pssl:6641:[::]",
+		OtherConfig: map[string]string{"foo": "bar"},
+		IsConnected: true,
+	}
+	desired := RemoteConnection(instance, current)
+	g.Expect(desired).To(Equal(ovsdb.Connection{
+		UUID:            "conn",
+		Target:          "pssl:6641:[::]",
+		InactivityProbe: ptr.To(60000),
+		MaxBackoff:      ptr.To(8000),
+		OtherConfig:     map[string]string{"foo": "bar", "dscp": "46"},
+		IsConnected:     true,
+	}))
+	g.Expect(ConnectionChanged(current, desired)).To(BeTrue())
+	g.Expect(ConnectionChanged(desired, RemoteConnection(instance, desired))).To(BeFalse())
+
+	// settings removed from the spec are cleared, 0 disables the probe
+	instance.Spec.InactivityProbe = ptr.To[int32](0)
+	instance.Spec.ExtraArgs.Remote = ovnv1.OVNDBRemoteOptions{}
+	cleared := RemoteConnection(instance, desired)
+	g.Expect(cleared.InactivityProbe).To(Equal(ptr.To(0)))
+	g.Expect(cleared.MaxBackoff).To(BeNil())
+	g.Expect(cleared.OtherConfig).To(Equal(map[string]string{"foo": "bar"}))
+	g.Expect(ConnectionChanged(desired, cleared)).To(BeTrue())
+	// the current connection is not modified
+	g.Expect(desired.OtherConfig).To(HaveKeyWithValue("dscp", "46"))
+}
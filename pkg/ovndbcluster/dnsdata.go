@@ -20,7 +20,7 @@ func DNSData(
 	ipList []string,
 	instance *ovnv1.OVNDBCluster,
 	serviceLabels map[string]string,
-) error {
+) (controllerutil.OperationResult, error) {
 	// ovsdbserver-(sb|nb) entry
 	headlessDNSHostname := serviceName + "." + instance.Namespace + ".svc"
 	dnsHosts := []infranetworkv1.DNSHost{}
@@ -44,7 +44,7 @@ func DNSData(
 		},
 	}
 
-	_, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), dnsData, func() error {
+	op, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), dnsData, func() error {
 		dnsData.Spec.Hosts = dnsHosts
 		// TODO: use value from DNSMasq instance instead of hardcode
 		dnsData.Spec.DNSDataLabelSelectorValue = "dnsdata"
@@ -55,9 +55,9 @@ func DNSData(
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("Error creating DNSData %s: %w", dnsData.Name, err)
+		return op, fmt.Errorf("Error creating DNSData %s: %w", dnsData.Name, err)
 	}
-	return nil
+	return op, nil
 }
 
 // GetDBAddress - return string connection for the given service
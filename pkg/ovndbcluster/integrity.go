@@ -0,0 +1,225 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// IntegrityCheckContainerName - container of the db pods checking the local member
+	IntegrityCheckContainerName = "integrity-check"
+
+	// IntegrityCheckAgentCommand - script running the integrity check of the
+	// local member every interval and reporting the result in the pod annotations
+	IntegrityCheckAgentCommand = "/usr/local/bin/container-scripts/integrity-check-agent.sh"
+
+	// IntegrityCheckAnnotation - output of the last integrity check of the member
+	IntegrityCheckAnnotation = "ovn.openstack.org/integrity-check"
+
+	// IntegrityCheckTimeAnnotation - time of the last integrity check of the member
+	IntegrityCheckTimeAnnotation = "ovn.openstack.org/integrity-check-time"
+)
+
+// IntegrityCheckContainer - the container of the db pods which checks the
+// local member every interval. It shares the run directory with the
+// ovsdb-server container to reach its unixctl and database sockets.
+func IntegrityCheckContainer(instance *ovnv1.OVNDBCluster, volumeMounts []corev1.VolumeMount) corev1.Container {
+	return corev1.Container{
+		Name:    IntegrityCheckContainerName,
+		Command: []string{IntegrityCheckAgentCommand},
+		Image:   instance.Spec.ContainerImage,
+		Env: []corev1.EnvVar{
+			{Name: "IntegrityCheckInterval", Value: strconv.Itoa(int(instance.Spec.IntegrityCheck.Interval))},
+			{Name: "IntegrityCheckAnnotation", Value: IntegrityCheckAnnotation},
+			{Name: "IntegrityCheckTimeAnnotation", Value: IntegrityCheckTimeAnnotation},
+			{
+				Name: "PodName",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+				},
+			},
+			{
+				Name: "PodNamespace",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+				},
+			},
+		},
+		VolumeMounts:             volumeMounts,
+		Resources:                ovn_common.ContainerResources(instance.Spec.ContainerResources.IntegrityCheck, instance.Spec.Resources),
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+}
+
+// MemberIntegrity - the result of the last integrity check reported by the
+// integrity-check container of the pod, false if the member wasn't checked yet
+func MemberIntegrity(pod corev1.Pod) (ovnv1.OVNDBMemberIntegrity, bool) {
+	output, ok := pod.Annotations[IntegrityCheckAnnotation]
+	if !ok {
+		return ovnv1.OVNDBMemberIntegrity{}, false
+	}
+	member := ParseIntegrityCheck(pod.Name, output)
+	checkTime, err := time.Parse(time.RFC3339, pod.Annotations[IntegrityCheckTimeAnnotation])
+	if err != nil {
+		return ovnv1.OVNDBMemberIntegrity{}, false
+	}
+	member.CheckTime = metav1.NewTime(checkTime)
+	return member, true
+}
+
+// NewIntegrityResults - returns whether any member was checked since the
+// previous results
+func NewIntegrityResults(members []ovnv1.OVNDBMemberIntegrity, previous []ovnv1.OVNDBMemberIntegrity) bool {
+	checked := map[string]metav1.Time{}
+	for _, p := range previous {
+		checked[p.Name] = p.CheckTime
+	}
+	for _, m := range members {
+		if t, ok := checked[m.Name]; !ok || !t.Equal(&m.CheckTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseIntegrityCheck - parse the key=value output of the check-db.sh script
+func ParseIntegrityCheck(podName string, output string) ovnv1.OVNDBMemberIntegrity {
+	member := ovnv1.OVNDBMemberIntegrity{
+		Name: podName,
+	}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "valid":
+			member.Valid = value == "true"
+		case "error":
+			member.Error = strings.TrimSpace(value)
+		case "applied_index":
+			index, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				member.Error = fmt.Sprintf("invalid applied index %q", value)
+				continue
+			}
+			member.AppliedIndex = index
+		case "checksum":
+			member.Checksum = value
+		}
+	}
+	return member
+}
+
+// IntegrityProblems - compare the members of the cluster and return the list of problems found.
+// previous is the result of the last check and is used to detect members which stopped applying
+// raft log entries.
+func IntegrityProblems(
+	members []ovnv1.OVNDBMemberIntegrity,
+	previous *ovnv1.OVNDBClusterIntegrityStatus,
+) []string {
+	problems := []string{}
+
+	checksums := map[int64]map[string][]string{}
+	for _, m := range members {
+		if m.Error != "" {
+			problems = append(problems, fmt.Sprintf("%s: %s", m.Name, m.Error))
+			continue
+		}
+		if !m.Valid {
+			problems = append(problems, fmt.Sprintf("%s: database file failed validation", m.Name))
+			continue
+		}
+		if m.Checksum == "" {
+			continue
+		}
+		if checksums[m.AppliedIndex] == nil {
+			checksums[m.AppliedIndex] = map[string][]string{}
+		}
+		checksums[m.AppliedIndex][m.Checksum] = append(checksums[m.AppliedIndex][m.Checksum], m.Name)
+	}
+
+	// members which applied the same raft log entries must have the same contents
+	indexes := []int64{}
+	for index := range checksums {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	for _, index := range indexes {
+		if len(checksums[index]) < 2 {
+			continue
+		}
+		groups := []string{}
+		for _, names := range checksums[index] {
+			groups = append(groups, "["+strings.Join(names, ",")+"]")
+		}
+		sort.Strings(groups)
+		problems = append(problems, fmt.Sprintf(
+			"members %s disagree on the database contents at index %d", strings.Join(groups, " "), index))
+	}
+
+	if previous != nil {
+		problems = append(problems, stuckMembers(members, previous.Members)...)
+	}
+
+	return problems
+}
+
+// stuckMembers - the members are checked independently, so a member behind
+// the others might only be lagging. A member is stuck if it was checked again
+// without applying any entry, while another member had already applied a
+// later entry before its previous check, i.e. it had a whole interval to
+// apply it.
+func stuckMembers(members []ovnv1.OVNDBMemberIntegrity, previous []ovnv1.OVNDBMemberIntegrity) []string {
+	problems := []string{}
+
+	healthy := func(m ovnv1.OVNDBMemberIntegrity) bool {
+		return m.Error == "" && m.Valid && !m.CheckTime.IsZero()
+	}
+	observed := append(append([]ovnv1.OVNDBMemberIntegrity{}, previous...), members...)
+
+	for _, m := range members {
+		if !healthy(m) {
+			continue
+		}
+		for _, p := range previous {
+			if p.Name != m.Name || !healthy(p) ||
+				!p.CheckTime.Before(&m.CheckTime) || p.AppliedIndex != m.AppliedIndex {
+				continue
+			}
+			for _, o := range observed {
+				if o.Name == m.Name || !healthy(o) ||
+					o.AppliedIndex <= m.AppliedIndex || o.CheckTime.After(p.CheckTime.Time) {
+					continue
+				}
+				problems = append(problems, fmt.Sprintf(
+					"%s: applied index %d did not advance since %s, while %s was at %d",
+					m.Name, m.AppliedIndex, p.CheckTime.UTC().Format(time.RFC3339), o.Name, o.AppliedIndex))
+				break
+			}
+		}
+	}
+
+	return problems
+}
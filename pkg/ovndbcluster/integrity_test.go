@@ -0,0 +1,203 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// output of check-db.sh on a healthy clustered member
+const checkOutputClustered = `valid=true
+applied_index=1042
+checksum=9f2c1a7e5b0d4e3f8a6b2c1d0e9f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f
+`
+
+// output of check-db.sh on a member with a corrupted database file
+const checkOutputInvalid = `valid=false
+error=ovsdb-tool: /etc/ovn/ovnnb_db.db: 1042: record 7 has inconsistent term 3 (expected 4)
+`
+
+// output of check-db.sh on a standalone database
+const checkOutputStandalone = `valid=true
+`
+
+func member(name string, index int64, checksum string, checkTime time.Time) ovnv1.OVNDBMemberIntegrity {
+	return ovnv1.OVNDBMemberIntegrity{
+		Name:         name,
+		CheckTime:    metav1.NewTime(checkTime),
+		Valid:        true,
+		AppliedIndex: index,
+		Checksum:     checksum,
+	}
+}
+
+func TestParseIntegrityCheck(t *testing.T) {
+	g := NewWithT(t)
+
+	m := ParseIntegrityCheck("ovsdbserver-nb-0", checkOutputClustered)
+	g.Expect(m).To(Equal(ovnv1.OVNDBMemberIntegrity{
+		Name:         "ovsdbserver-nb-0",
+		Valid:        true,
+		AppliedIndex: 1042,
+		Checksum:     "9f2c1a7e5b0d4e3f8a6b2c1d0e9f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f",
+	}))
+
+	m = ParseIntegrityCheck("ovsdbserver-nb-1", checkOutputInvalid)
+	g.Expect(m.Valid).To(BeFalse())
+	g.Expect(m.Error).To(Equal("ovsdb-tool: /etc/ovn/ovnnb_db.db: 1042: record 7 has inconsistent term 3 (expected 4)"))
+
+	m = ParseIntegrityCheck("ovsdbserver-nb-0", checkOutputStandalone)
+	g.Expect(m.Valid).To(BeTrue())
+	g.Expect(m.AppliedIndex).To(BeZero())
+	g.Expect(m.Checksum).To(BeEmpty())
+
+	// the applied index can't be computed when cluster/status fails
+	m = ParseIntegrityCheck("ovsdbserver-nb-2", "valid=true\napplied_index=\n")
+	g.Expect(m.Error).To(Equal(`invalid applied index ""`))
+}
+
+func TestMemberIntegrity(t *testing.T) {
+	g := NewWithT(t)
+
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovsdbserver-nb-0"},
+	}
+	_, ok := MemberIntegrity(pod)
+	g.Expect(ok).To(BeFalse())
+
+	pod.Annotations = map[string]string{
+		IntegrityCheckAnnotation:     checkOutputClustered,
+		IntegrityCheckTimeAnnotation: "2024-05-06T07:08:09Z",
+	}
+	m, ok := MemberIntegrity(pod)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(m.Name).To(Equal("ovsdbserver-nb-0"))
+	g.Expect(m.AppliedIndex).To(Equal(int64(1042)))
+	g.Expect(m.CheckTime.Time).To(Equal(time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)))
+}
+
+func TestIntegrityProblems(t *testing.T) {
+	t0 := time.Date(2024, 5, 6, 7, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		members  []ovnv1.OVNDBMemberIntegrity
+		previous []ovnv1.OVNDBMemberIntegrity
+		problems []string
+	}{
+		{
+			name: "healthy",
+			members: []ovnv1.OVNDBMemberIntegrity{
+				member("nb-0", 1042, "aaaa", t0),
+				member("nb-1", 1042, "aaaa", t0.Add(time.Minute)),
+				member("nb-2", 1043, "bbbb", t0.Add(2*time.Minute)),
+			},
+		},
+		{
+			name: "invalid database file",
+			members: []ovnv1.OVNDBMemberIntegrity{
+				member("nb-0", 1042, "aaaa", t0),
+				ParseIntegrityCheck("nb-1", checkOutputInvalid),
+			},
+			problems: []string{
+				"nb-1: ovsdb-tool: /etc/ovn/ovnnb_db.db: 1042: record 7 has inconsistent term 3 (expected 4)",
+			},
+		},
+		{
+			name: "contents differ at the same index",
+			members: []ovnv1.OVNDBMemberIntegrity{
+				member("nb-0", 1042, "aaaa", t0),
+				member("nb-1", 1042, "aaaa", t0),
+				member("nb-2", 1042, "cccc", t0),
+			},
+			problems: []string{
+				"members [nb-0,nb-1] [nb-2] disagree on the database contents at index 1042",
+			},
+		},
+		{
+			name: "lagging member is not stuck",
+			previous: []ovnv1.OVNDBMemberIntegrity{
+				member("nb-0", 1042, "aaaa", t0),
+				member("nb-1", 1043, "bbbb", t0.Add(time.Minute)),
+			},
+			members: []ovnv1.OVNDBMemberIntegrity{
+				// nb-1 applied 1043 after nb-0 was checked, nb-0 may have
+				// applied it in the meantime if there was no later entry
+				member("nb-0", 1042, "aaaa", t1),
+				member("nb-1", 1043, "bbbb", t1.Add(time.Minute)),
+			},
+		},
+		{
+			name: "member not checked again is not stuck",
+			previous: []ovnv1.OVNDBMemberIntegrity{
+				member("nb-0", 1042, "aaaa", t0),
+				member("nb-1", 1043, "bbbb", t0.Add(-time.Minute)),
+			},
+			members: []ovnv1.OVNDBMemberIntegrity{
+				member("nb-0", 1042, "aaaa", t0),
+				member("nb-1", 1050, "dddd", t1),
+			},
+		},
+		{
+			name: "stuck member",
+			previous: []ovnv1.OVNDBMemberIntegrity{
+				member("nb-0", 1042, "aaaa", t0),
+				member("nb-1", 1043, "bbbb", t0.Add(-time.Minute)),
+			},
+			members: []ovnv1.OVNDBMemberIntegrity{
+				member("nb-0", 1042, "aaaa", t1),
+				member("nb-1", 1050, "dddd", t1),
+			},
+			problems: []string{
+				"nb-0: applied index 1042 did not advance since 2024-05-06T07:00:00Z, while nb-1 was at 1043",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var previous *ovnv1.OVNDBClusterIntegrityStatus
+			if tt.previous != nil {
+				previous = &ovnv1.OVNDBClusterIntegrityStatus{Members: tt.previous}
+			}
+			problems := IntegrityProblems(tt.members, previous)
+			if tt.problems == nil {
+				g.Expect(problems).To(BeEmpty())
+			} else {
+				g.Expect(problems).To(Equal(tt.problems))
+			}
+		})
+	}
+}
+
+func TestNewIntegrityResults(t *testing.T) {
+	g := NewWithT(t)
+
+	t0 := time.Date(2024, 5, 6, 7, 0, 0, 0, time.UTC)
+	previous := []ovnv1.OVNDBMemberIntegrity{member("nb-0", 1042, "aaaa", t0)}
+
+	g.Expect(NewIntegrityResults(previous, previous)).To(BeFalse())
+	// a member checked in the same second as the previous one
+	g.Expect(NewIntegrityResults(append(previous, member("nb-1", 1042, "aaaa", t0)), previous)).To(BeTrue())
+	g.Expect(NewIntegrityResults([]ovnv1.OVNDBMemberIntegrity{member("nb-0", 1042, "aaaa", t0.Add(time.Hour))}, previous)).To(BeTrue())
+}
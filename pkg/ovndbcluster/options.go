@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// ConfigCheckInterval - how often the database options and the settings
+	// of the remote connections are checked for drift
+	ConfigCheckInterval = 60 * time.Second
+	// ConfigTimeout - time the operator has to connect to the database and
+	// update its configuration
+	ConfigTimeout = 10 * time.Second
+)
+
+// GlobalTable - NB_Global or SB_Global
+func GlobalTable(dbType string) string {
+	return fmt.Sprintf("%s_Global", strings.ToUpper(dbType))
+}
+
+// AppliedOptions - returns the current value of each of the desired options,
+// options which are not set in the database are left out
+func AppliedOptions(current map[string]string, desired map[string]string) map[string]string {
+	var applied map[string]string
+	for key := range desired {
+		value, ok := current[key]
+		if !ok {
+			continue
+		}
+		if applied == nil {
+			applied = map[string]string{}
+		}
+		applied[key] = value
+	}
+	return applied
+}
+
+// OptionsChanges - returns the options which have to be set to match
+// desired, and the previously applied options which have to be removed
+func OptionsChanges(
+	current map[string]string,
+	desired map[string]string,
+	applied map[string]string,
+) (map[string]string, []string) {
+	set := map[string]string{}
+	for key, value := range desired {
+		if current[key] != value {
+			set[key] = value
+		}
+	}
+	remove := []string{}
+	for key := range applied {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		if _, ok := current[key]; ok {
+			remove = append(remove, key)
+		}
+	}
+	return set, remove
+}
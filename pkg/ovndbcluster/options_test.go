@@ -0,0 +1,69 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+)
+
+func TestOptionsChanges(t *testing.T) {
+	g := NewWithT(t)
+
+	current := map[string]string{
+		"mac_prefix":            "0a:00:01",
+		"northd_probe_interval": "5000",
+		"use_logical_dp_groups": "true",
+		"svc_monitor_mac":       "0a:00:00:00:00:01",
+	}
+	desired := map[string]string{
+		"mac_prefix":            "0a:00:00",
+		"northd_probe_interval": "5000",
+		"northd_backoff":        "100",
+	}
+	applied := map[string]string{
+		"mac_prefix":            "0a:00:00",
+		"northd_probe_interval": "5000",
+		"use_logical_dp_groups": "true",
+		"gone":                  "1",
+	}
+
+	set, remove := OptionsChanges(current, desired, applied)
+	// drifted and new options are set, unchanged ones are not
+	g.Expect(set).To(Equal(map[string]string{
+		"mac_prefix":     "0a:00:00",
+		"northd_backoff": "100",
+	}))
+	// options removed from the spec are removed if they are still set,
+	// options never managed by the operator are left untouched
+	g.Expect(remove).To(ConsistOf("use_logical_dp_groups"))
+
+	set, remove = OptionsChanges(current, map[string]string{}, nil)
+	g.Expect(set).To(BeEmpty())
+	g.Expect(remove).To(BeEmpty())
+}
+
+func TestAppliedOptions(t *testing.T) {
+	g := NewWithT(t)
+
+	current := map[string]string{
+		"mac_prefix":      "0a:00:00",
+		"svc_monitor_mac": "0a:00:00:00:00:01",
+	}
+	g.Expect(AppliedOptions(current, map[string]string{
+		"mac_prefix":            "0a:00:01",
+		"northd_probe_interval": "5000",
+	})).To(Equal(map[string]string{"mac_prefix": "0a:00:00"}))
+	g.Expect(AppliedOptions(current, nil)).To(BeNil())
+}
@@ -0,0 +1,51 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// PodDisruptionBudget - returns the PodDisruptionBudget spec of the members.
+// In clustered mode a majority of the members has to stay available to keep
+// the RAFT quorum, in active-backup mode one member has to stay available.
+// The quorum of two members needs both of them, protecting it would block
+// node drains, so only one member may be disrupted at a time.
+func PodDisruptionBudget(
+	instance *ovnv1.OVNDBCluster,
+	labels map[string]string,
+) *policyv1.PodDisruptionBudgetSpec {
+	replicas := instance.Spec.GetReplicas()
+
+	var minAvailable, maxUnavailable *intstr.IntOrString
+	// a single member can't be protected without blocking node drains
+	if replicas > 1 {
+		switch instance.Spec.Mode {
+		case ovnv1.DBModeStandalone:
+		case ovnv1.DBModeActiveBackup:
+			minAvailable = ptr.To(intstr.FromInt32(1))
+		default:
+			if replicas == 2 {
+				maxUnavailable = ptr.To(intstr.FromInt32(1))
+			} else {
+				minAvailable = ptr.To(intstr.FromInt32(replicas/2 + 1))
+			}
+		}
+	}
+
+	return ovn_common.PodDisruptionBudgetSpec(labels, minAvailable, maxUnavailable, instance.Spec.Override.PodDisruptionBudget)
+}
@@ -17,6 +17,8 @@ package ovndbcluster
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,6 +26,7 @@ import (
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
 )
 
 // OVNDBPods - Query current running ovn db pods managed by the statefulset
@@ -36,3 +39,24 @@ func OVNDBPods(
 	podSelectorString := k8s_labels.Set(serviceLabels).String()
 	return helper.GetKClient().CoreV1().Pods(instance.Namespace).List(ctx, metav1.ListOptions{LabelSelector: podSelectorString})
 }
+
+// AppctlCommand - command to run unixctl commands against the ovsdb-server of dbType
+func AppctlCommand(dbType string) string {
+	return fmt.Sprintf("ovs-appctl -t /tmp/ovn%s_db.ctl", strings.ToLower(dbType))
+}
+
+// VersionProbes - queries the ovsdb-server version and the OVN release of the database pods
+func VersionProbes(container string, dbType string) []ovn_common.VersionProbe {
+	return []ovn_common.VersionProbe{
+		{
+			Component: ovn_common.ComponentOVSDBServer,
+			Container: container,
+			Command:   []string{"ovsdb-server", "--version"},
+		},
+		{
+			Component: ovn_common.ComponentOVN,
+			Container: container,
+			Command:   []string{fmt.Sprintf("ovn-%sctl", strings.ToLower(dbType)), "--version"},
+		},
+	}
+}
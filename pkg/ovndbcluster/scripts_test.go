@@ -0,0 +1,205 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovndbcluster
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+)
+
+// ovsdb-tool stub, the database files contain the mode of the database and
+// the calls are logged to $CALLS
+const ovsdbToolStub = `#!/bin/bash
+echo "$*" >> "${CALLS}"
+case "$1" in
+    db-is-clustered) grep -q '^clustered' "$2" ;;
+    db-is-standalone) grep -q '^standalone' "$2" ;;
+    cluster-to-standalone) echo "standalone" > "$2" ;;
+    create-cluster) echo "clustered $4" > "$2" ;;
+esac
+`
+
+// convertDBFile - runs convert_db_file of the functions script rendered for
+// the mode on the given member with a database file in the given mode and
+// returns the contents of the database file after the conversion and the
+// ovsdb-tool calls
+func convertDBFile(t *testing.T, mode string, hostname string, activeMember string, dbFile string) (string, string) {
+	g := NewWithT(t)
+
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash is not available")
+	}
+
+	tmpl, err := template.ParseFiles(filepath.Join("..", "..", "templates", "ovndbcluster", "bin", "functions"))
+	g.Expect(err).ToNot(HaveOccurred())
+	dir := t.TempDir()
+	functions, err := os.Create(filepath.Join(dir, "functions"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(tmpl.Execute(functions, map[string]string{
+		"DB_TYPE":      "nb",
+		"MODE":         mode,
+		"SERVICE_NAME": ovnv1.ServiceNameNB,
+	})).To(Succeed())
+	g.Expect(functions.Close()).To(Succeed())
+
+	bin := filepath.Join(dir, "bin")
+	g.Expect(os.Mkdir(bin, 0755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(bin, "ovsdb-tool"), []byte(ovsdbToolStub), 0755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(bin, "hostname"), []byte("#!/bin/bash\necho "+hostname+"\n"), 0755)).To(Succeed())
+
+	db := filepath.Join(dir, "ovnnb_db.db")
+	if dbFile != "" {
+		g.Expect(os.WriteFile(db, []byte(dbFile+"\n"), 0644)).To(Succeed())
+	}
+
+	cmd := exec.Command(bash, "-c", `source "$1"; DB_FILE="$2"; convert_db_file tcp:local:6643`,
+		"bash", functions.Name(), db)
+	cmd.Env = append(os.Environ(),
+		"PATH="+bin+string(os.PathListSeparator)+os.Getenv("PATH"),
+		"CALLS="+filepath.Join(dir, "calls"),
+		"ACTIVE_MEMBER="+activeMember,
+	)
+	out, err := cmd.CombinedOutput()
+	g.Expect(err).ToNot(HaveOccurred(), string(out))
+
+	contents, err := os.ReadFile(db)
+	if os.IsNotExist(err) {
+		contents = nil
+	} else {
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+	calls, err := os.ReadFile(filepath.Join(dir, "calls"))
+	if !os.IsNotExist(err) {
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+	g.Expect(os.ReadDir(dir)).ToNot(ContainElement(HaveField("Name()", "ovnnb_db_standalone.db")))
+
+	return strings.TrimSpace(string(contents)), string(calls)
+}
+
+func TestConvertDBFile(t *testing.T) {
+	const member0 = ovnv1.ServiceNameNB + "-0"
+	const member1 = ovnv1.ServiceNameNB + "-1"
+
+	tests := []struct {
+		name         string
+		mode         string
+		hostname     string
+		activeMember string
+		dbFile       string
+		expected     string
+		convert      string
+	}{
+		{
+			name:         "clustered to active-backup on the active member",
+			mode:         ovnv1.DBModeActiveBackup,
+			hostname:     member1,
+			activeMember: member1,
+			dbFile:       "clustered",
+			expected:     "standalone",
+			convert:      "cluster-to-standalone",
+		},
+		{
+			name:         "clustered to active-backup on a backup member",
+			mode:         ovnv1.DBModeActiveBackup,
+			hostname:     member0,
+			activeMember: member1,
+			dbFile:       "clustered",
+		},
+		{
+			name:         "clustered to standalone",
+			mode:         ovnv1.DBModeStandalone,
+			hostname:     member1,
+			activeMember: member1,
+			dbFile:       "clustered",
+			expected:     "standalone",
+			convert:      "cluster-to-standalone",
+		},
+		{
+			name:     "standalone to clustered on the first member",
+			mode:     ovnv1.DBModeClustered,
+			hostname: member0,
+			dbFile:   "standalone",
+			expected: "clustered tcp:local:6643",
+			convert:  "create-cluster",
+		},
+		{
+			name:     "standalone to clustered on another member",
+			mode:     ovnv1.DBModeClustered,
+			hostname: member1,
+			dbFile:   "standalone",
+		},
+		{
+			// after a failover the second member holds the data
+			name:         "active-backup to clustered on the active member",
+			mode:         ovnv1.DBModeClustered,
+			hostname:     member1,
+			activeMember: member1,
+			dbFile:       "standalone",
+			expected:     "clustered tcp:local:6643",
+			convert:      "create-cluster",
+		},
+		{
+			name:         "active-backup to clustered on the backup member",
+			mode:         ovnv1.DBModeClustered,
+			hostname:     member0,
+			activeMember: member1,
+			dbFile:       "standalone",
+		},
+		{
+			name:     "clustered stays clustered",
+			mode:     ovnv1.DBModeClustered,
+			hostname: member1,
+			dbFile:   "clustered",
+			expected: "clustered",
+		},
+		{
+			name:         "standalone stays standalone",
+			mode:         ovnv1.DBModeActiveBackup,
+			hostname:     member0,
+			activeMember: member1,
+			dbFile:       "standalone",
+			expected:     "standalone",
+		},
+		{
+			name:     "no database file",
+			mode:     ovnv1.DBModeClustered,
+			hostname: member0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			contents, calls := convertDBFile(t, tt.mode, tt.hostname, tt.activeMember, tt.dbFile)
+			g.Expect(contents).To(Equal(tt.expected))
+			for _, c := range []string{"cluster-to-standalone", "create-cluster"} {
+				if c == tt.convert {
+					g.Expect(calls).To(ContainSubstring(c))
+				} else {
+					g.Expect(calls).ToNot(ContainSubstring(c))
+				}
+			}
+		})
+	}
+}
@@ -13,8 +13,8 @@ limitations under the License.
 package ovndbcluster
 
 import (
-	"github.com/openstack-k8s-operators/lib-common/modules/common"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
+	"time"
+
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
@@ -33,6 +33,16 @@ const (
 
 	// PVCSuffixEtcOVN -
 	PVCSuffixEtcOVN = "-etc-ovn"
+
+	// PromoteCommand - script promoting a backup member to active
+	PromoteCommand = "/usr/local/bin/container-scripts/promote.sh"
+
+	// ActiveMemberKey - key of the runtime ConfigMap holding the active member
+	ActiveMemberKey = "active-member"
+
+	// FailoverPollInterval - interval of checking whether the former active
+	// member is fenced during a failover
+	FailoverPollInterval = 5 * time.Second
 )
 
 // StatefulSet func
@@ -100,6 +110,13 @@ func StatefulSet(
 	// before seizing file logging, and the default log file location is not
 	// available for write
 	envVars["OVN_LOGDIR"] = env.SetValue("/tmp")
+	// the active member is resolved on container start, this way a member
+	// restarted after a failover joins as backup without a rollout
+	envVars["ACTIVE_MEMBER"] = ovn_common.RuntimeConfigMapEnv(instance.Name, ActiveMemberKey)
+	// log levels are changed at runtime by the controller, the pods only pick
+	// them up on start
+	envVars["OVN_LOG_LEVEL"] = ovn_common.RuntimeConfigMapEnv(instance.Name, ovn_common.LogLevelKey)
+	envVars["OVN_LOG_MODULES"] = ovn_common.RuntimeConfigMapEnv(instance.Name, ovn_common.LogModulesKey)
 
 	// create Volume and VolumeMounts
 	volumes := GetDBClusterVolumes(instance.Name)
@@ -123,6 +140,13 @@ func StatefulSet(
 		volumeMounts = append(volumeMounts, svc.CreateVolumeMounts(serviceName)...)
 	}
 
+	// the integrity-check container reaches ovsdb-server through the sockets
+	// in the shared run directory
+	if instance.Spec.IntegrityCheck.Enabled {
+		volumes = append(volumes, GetRunDirVolume())
+		volumeMounts = append(volumeMounts, GetRunDirVolumeMount())
+	}
+
 	// NOTE(ihar) ovndb pods leave the raft cluster on delete; it's important
 	// that they are not interrupted and have a good chance to propagate the
 	// leave message to the leader. In general case, this should happen near
@@ -147,7 +171,7 @@ func StatefulSet(
 			},
 			ServiceName:         serviceName,
 			PodManagementPolicy: appsv1.ParallelPodManagement,
-			Replicas:            instance.Spec.Replicas,
+			Replicas:            ptr.To(instance.Spec.GetReplicas()),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: annotations,
@@ -164,7 +188,7 @@ func StatefulSet(
 							Image:                    instance.Spec.ContainerImage,
 							Env:                      env.MergeEnvs([]corev1.EnvVar{}, envVars),
 							VolumeMounts:             volumeMounts,
-							Resources:                instance.Spec.Resources,
+							Resources:                ovn_common.ContainerResources(instance.Spec.ContainerResources.OVSDBServer, instance.Spec.Resources),
 							ReadinessProbe:           readinessProbe,
 							LivenessProbe:            livenessProbe,
 							StartupProbe:             startupProbe,
@@ -209,16 +233,19 @@ func StatefulSet(
 		},
 	}
 	statefulset.Spec.Template.Spec.Volumes = volumes
+	if instance.Spec.IntegrityCheck.Enabled {
+		statefulset.Spec.Template.Spec.Containers = append(statefulset.Spec.Template.Spec.Containers,
+			IntegrityCheckContainer(instance, volumeMounts))
+	}
 	// If possible two pods of the same service should not
 	// run on the same worker node. If this is not possible
-	// the get still created on the same worker node.
-	statefulset.Spec.Template.Spec.Affinity = affinity.DistributePods(
-		common.AppSelector,
-		[]string{
-			serviceName,
-		},
-		corev1.LabelHostname,
-	)
+	// the get still created on the same worker node, unless
+	// the anti-affinity is required.
+	statefulset.Spec.Template.Spec.Affinity = ovn_common.PodAntiAffinity(serviceName, instance.Spec.AntiAffinity)
+	statefulset.Spec.Template.Spec.TopologySpreadConstraints = ovn_common.TopologySpreadConstraints(
+		instance.Spec.TopologySpreadConstraints, labels)
+	statefulset.Spec.Template.Spec.Tolerations = instance.Spec.Tolerations
+	statefulset.Spec.Template.Spec.PriorityClassName = instance.Spec.PriorityClassName
 	if instance.Spec.NodeSelector != nil {
 		statefulset.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
 	}
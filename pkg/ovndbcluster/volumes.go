@@ -40,3 +40,22 @@ func GetDBClusterVolumeMounts(name string) []corev1.VolumeMount {
 	}
 
 }
+
+// GetRunDirVolume - run directory shared by the containers of the db pods,
+// it holds the unixctl and database sockets of ovsdb-server
+func GetRunDirVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: "ovn-rundir",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+// GetRunDirVolumeMount - mount of the shared run directory
+func GetRunDirVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      "ovn-rundir",
+		MountPath: "/tmp",
+	}
+}
@@ -15,8 +15,6 @@ package ovnnorthd
 import (
 	"fmt"
 
-	"github.com/openstack-k8s-operators/lib-common/modules/common"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
@@ -31,8 +29,27 @@ import (
 const (
 	// ServiceCommand -
 	ServiceCommand = "/usr/bin/ovn-northd"
+
+	// StartCommand - script starting ovn-northd with the log levels of the
+	// runtime ConfigMap
+	StartCommand = "/usr/local/bin/container-scripts/ovn_northd_start.sh"
+
+	// UnixctlSocket - control socket of ovn-northd
+	UnixctlSocket = "/tmp/ovn-northd.ctl"
+
+	// AppctlCommand - command to run unixctl commands against ovn-northd
+	AppctlCommand = "ovn-appctl -t " + UnixctlSocket
 )
 
+// VersionProbes - queries the ovn-northd version
+var VersionProbes = []ovn_common.VersionProbe{
+	{
+		Component: ovn_common.ComponentOVNNorthd,
+		Container: ovnv1.ServiceNameOVNNorthd,
+		Command:   []string{ServiceCommand, "--version"},
+	},
+}
+
 // Deployment func
 func Deployment(
 	instance *ovnv1.OVNNorthd,
@@ -43,22 +60,22 @@ func Deployment(
 ) *appsv1.Deployment {
 
 	livenessProbe := &corev1.Probe{
-		// TODO might need tuning
-		TimeoutSeconds:      5,
-		PeriodSeconds:       3,
-		InitialDelaySeconds: 3,
+		TimeoutSeconds:      instance.Spec.Probes.TimeoutSeconds,
+		PeriodSeconds:       instance.Spec.Probes.PeriodSeconds,
+		InitialDelaySeconds: instance.Spec.Probes.InitialDelaySeconds,
+		FailureThreshold:    instance.Spec.Probes.FailureThreshold,
 	}
 	readinessProbe := &corev1.Probe{
-		// TODO might need tuning
-		TimeoutSeconds:      5,
-		PeriodSeconds:       5,
-		InitialDelaySeconds: 5,
+		TimeoutSeconds:      instance.Spec.Probes.TimeoutSeconds,
+		PeriodSeconds:       instance.Spec.Probes.PeriodSeconds,
+		InitialDelaySeconds: instance.Spec.Probes.InitialDelaySeconds,
+		FailureThreshold:    instance.Spec.Probes.FailureThreshold,
 	}
-	cmd := []string{ServiceCommand}
+	cmd := []string{StartCommand}
 	args := []string{
 		"-vfile:off",
-		fmt.Sprintf("-vconsole:%s", instance.Spec.LogLevel),
-		fmt.Sprintf("--n-threads=%d", *instance.Spec.NThreads),
+		fmt.Sprintf("--unixctl=%s", UnixctlSocket),
+		fmt.Sprintf("--n-threads=%d", NThreads(instance)),
 		fmt.Sprintf("--ovnnb-db=%s", nbEndpoint),
 		fmt.Sprintf("--ovnsb-db=%s", sbEndpoint),
 	}
@@ -91,18 +108,66 @@ func Deployment(
 		)
 	}
 
+	// options validated against OVNNorthdAllowedOptions by the webhook
+	args = append(args, instance.Spec.ExtraArgs...)
+
 	//
 	// https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/
 	//
 	livenessProbe.Exec = &corev1.ExecAction{
 		Command: []string{
-			"/usr/bin/pidof", "ovn-northd",
+			"/usr/local/bin/container-scripts/ovn_northd_liveness.sh",
 		},
 	}
-	readinessProbe.Exec = livenessProbe.Exec
+	readinessProbe.Exec = &corev1.ExecAction{
+		Command: []string{
+			"/usr/local/bin/container-scripts/ovn_northd_readiness.sh",
+		},
+	}
+	volumes = append(volumes, GetScriptsVolume(instance.Name))
+	volumeMounts = append(volumeMounts, GetScriptsVolumeMount())
 
 	// TODO: Make confs customizable
 	envVars["OVN_RUNDIR"] = env.SetValue("/tmp")
+	envVars["OVN_NORTHD_APPCTL_TIMEOUT"] = env.SetValue(fmt.Sprintf("%d", appctlTimeout(instance.Spec.Probes.TimeoutSeconds)))
+	envVars["OVN_LOG_LEVEL"] = ovn_common.RuntimeConfigMapEnv(instance.Name, ovn_common.LogLevelKey)
+	envVars["OVN_LOG_MODULES"] = ovn_common.RuntimeConfigMapEnv(instance.Name, ovn_common.LogModulesKey)
+
+	containers := []corev1.Container{
+		{
+			Name:                     ovnv1.ServiceNameOVNNorthd,
+			Command:                  cmd,
+			Args:                     args,
+			Image:                    instance.Spec.ContainerImage,
+			SecurityContext:          getOVNNorthdSecurityContext(),
+			Env:                      env.MergeEnvs([]corev1.EnvVar{}, envVars),
+			Resources:                instance.Spec.Resources,
+			ReadinessProbe:           readinessProbe,
+			LivenessProbe:            livenessProbe,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			VolumeMounts:             volumeMounts,
+		},
+	}
+
+	// the sidecar reaches the unixctl socket through the shared run directory
+	if instance.Spec.Metrics.Enabled {
+		volumes = append(volumes, GetRundirVolume())
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, GetRundirVolumeMount())
+		containers = append(containers, corev1.Container{
+			Name:    MetricsContainer,
+			Command: []string{"/usr/local/bin/container-scripts/ovn_northd_metrics.sh"},
+			Image:   instance.Spec.ContainerImage,
+			Env: []corev1.EnvVar{
+				{
+					Name:  "OVN_NORTHD_METRICS_INTERVAL",
+					Value: fmt.Sprintf("%d", instance.Spec.Metrics.IntervalSeconds),
+				},
+			},
+			SecurityContext:          getOVNNorthdSecurityContext(),
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			VolumeMounts:             []corev1.VolumeMount{GetScriptsVolumeMount(), GetRundirVolumeMount()},
+		})
+	}
 
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -113,46 +178,40 @@ func Deployment(
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
-			Replicas: instance.Spec.Replicas,
+			Replicas: ptr.To(instance.Spec.GetReplicas()),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: labels,
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: instance.RbacResourceName(),
-					Containers: []corev1.Container{
-						{
-							Name:                     ovnv1.ServiceNameOVNNorthd,
-							Command:                  cmd,
-							Args:                     args,
-							Image:                    instance.Spec.ContainerImage,
-							SecurityContext:          getOVNNorthdSecurityContext(),
-							Env:                      env.MergeEnvs([]corev1.EnvVar{}, envVars),
-							Resources:                instance.Spec.Resources,
-							ReadinessProbe:           readinessProbe,
-							LivenessProbe:            livenessProbe,
-							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
-							VolumeMounts:             volumeMounts,
-						},
-					},
-					Volumes: volumes,
+					Containers:         containers,
+					Volumes:            volumes,
 				},
 			},
 		},
 	}
 	// If possible two pods of the same service should not
 	// run on the same worker node. If this is not possible
-	// the get still created on the same worker node.
-	deployment.Spec.Template.Spec.Affinity = affinity.DistributePods(
-		common.AppSelector,
-		[]string{
-			ovnv1.ServiceNameOVNNorthd,
-		},
-		corev1.LabelHostname,
-	)
+	// the get still created on the same worker node, unless
+	// the anti-affinity is required.
+	deployment.Spec.Template.Spec.Affinity = ovn_common.PodAntiAffinity(ovnv1.ServiceNameOVNNorthd, instance.Spec.AntiAffinity)
+	deployment.Spec.Template.Spec.TopologySpreadConstraints = ovn_common.TopologySpreadConstraints(
+		instance.Spec.TopologySpreadConstraints, labels)
+	deployment.Spec.Template.Spec.Tolerations = instance.Spec.Tolerations
+	deployment.Spec.Template.Spec.PriorityClassName = instance.Spec.PriorityClassName
 	if instance.Spec.NodeSelector != nil {
 		deployment.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
 	}
 
 	return deployment
 }
+
+// appctlTimeout - the time the ovn-appctl calls of a probe may take, shorter
+// than the probe timeout so the probe fails with the error of ovn-appctl
+func appctlTimeout(probeTimeout int32) int32 {
+	if probeTimeout <= 2 {
+		return 1
+	}
+	return probeTimeout - 1
+}
@@ -0,0 +1,37 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovnnorthd
+
+import (
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// PodDisruptionBudget - returns the PodDisruptionBudget spec of the northd
+// pods. Only one ovn-northd is active at a time, so one pod at a time may be
+// disrupted while a standby takes over.
+func PodDisruptionBudget(
+	instance *ovnv1.OVNNorthd,
+	labels map[string]string,
+) *policyv1.PodDisruptionBudgetSpec {
+	var maxUnavailable *intstr.IntOrString
+	// a single pod can't be protected without blocking node drains
+	if instance.Spec.GetReplicas() > 1 {
+		maxUnavailable = ptr.To(intstr.FromInt32(1))
+	}
+
+	return ovn_common.PodDisruptionBudgetSpec(labels, nil, maxUnavailable, instance.Spec.Override.PodDisruptionBudget)
+}
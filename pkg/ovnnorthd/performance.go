@@ -0,0 +1,123 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovnnorthd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MetricsContainer - name of the metrics sidecar
+	MetricsContainer = "ovn-northd-metrics"
+
+	// StopwatchFile - file the metrics sidecar writes the stopwatch/show
+	// output to, in the run directory shared with ovn-northd
+	StopwatchFile = "/tmp/ovn-northd-stopwatch"
+
+	// LoopStopwatch - stopwatch of the ovn-northd main loop
+	LoopStopwatch = "ovn-northd-loop"
+	// ProcessingStopwatch - stopwatch of the processing of the OVN_Northbound changes
+	ProcessingStopwatch = "ovnnb_db_run"
+)
+
+// StopwatchCommand - reads the last sample written by the metrics sidecar
+var StopwatchCommand = []string{"cat", StopwatchFile}
+
+// Stopwatch - statistics of an ovn-northd stopwatch
+type Stopwatch struct {
+	Samples     int64
+	Maximum     time.Duration
+	LongTermAvg time.Duration
+}
+
+// ParseStopwatches - parse the output of ovn-appctl stopwatch/show, e.g.
+//
+//	Statistics for 'ovn-northd-loop'
+//	  Total samples: 56
+//	  Maximum: 38 msec
+//	  Minimum: 0 msec
+//	  95th percentile: 13.218300 msec
+//	  Short term average: 2.604081 msec
+//	  Long term average: 2.119476 msec
+func ParseStopwatches(out string) (map[string]Stopwatch, error) {
+	stopwatches := map[string]Stopwatch{}
+	name := ""
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Statistics for '") {
+			name = strings.TrimSuffix(strings.TrimPrefix(line, "Statistics for '"), "'")
+			stopwatches[name] = Stopwatch{}
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found || name == "" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		sw := stopwatches[name]
+		var err error
+		switch key {
+		case "Total samples":
+			sw.Samples, err = strconv.ParseInt(value, 10, 64)
+		case "Maximum":
+			sw.Maximum, err = parseStopwatchDuration(value)
+		case "Long term average":
+			sw.LongTermAvg, err = parseStopwatchDuration(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s of stopwatch %s: %w", key, name, err)
+		}
+		stopwatches[name] = sw
+	}
+	if len(stopwatches) == 0 {
+		return nil, fmt.Errorf("no stopwatch statistics found")
+	}
+	return stopwatches, nil
+}
+
+// parseStopwatchDuration - parse a stopwatch value like "2.119476 msec"
+func parseStopwatchDuration(value string) (time.Duration, error) {
+	number, unit, _ := strings.Cut(value, " ")
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, err
+	}
+	switch unit {
+	case "msec":
+		return time.Duration(f * float64(time.Millisecond)), nil
+	case "usec":
+		return time.Duration(f * float64(time.Microsecond)), nil
+	case "nsec":
+		return time.Duration(f), nil
+	}
+	return 0, fmt.Errorf("unknown unit %q", unit)
+}
+
+// Performance - the loop and processing times reported in the status
+func Performance(stopwatches map[string]Stopwatch) ovnv1.OVNNorthdPerformance {
+	loop := stopwatches[LoopStopwatch]
+	processing := stopwatches[ProcessingStopwatch]
+	return ovnv1.OVNNorthdPerformance{
+		LoopTime:          metav1.Duration{Duration: loop.LongTermAvg},
+		LoopTimeMax:       metav1.Duration{Duration: loop.Maximum},
+		ProcessingTime:    metav1.Duration{Duration: processing.LongTermAvg},
+		ProcessingTimeMax: metav1.Duration{Duration: processing.Maximum},
+		Samples:           loop.Samples,
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovnnorthd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// StatusActive - the instance holds the OVN_Southbound lock
+	StatusActive = "active"
+	// StatusStandby - the instance waits for the OVN_Southbound lock
+	StatusStandby = "standby"
+	// StatusPaused - the instance was paused and doesn't compete for the lock
+	StatusPaused = "paused"
+
+	// StatusCheckInterval - how often the active instance is checked when
+	// running more than one replica
+	StatusCheckInterval = 30 * time.Second
+)
+
+// StatusCommand - queries whether ovn-northd is active, standby or paused
+var StatusCommand = []string{"ovn-appctl", "-t", UnixctlSocket, "status"}
+
+// ParseStatus - returns the state from the output of the status command, e.g.
+// "Status: active"
+func ParseStatus(output string) (string, error) {
+	for _, line := range strings.Split(output, "\n") {
+		if state, found := strings.CutPrefix(strings.TrimSpace(line), "Status:"); found {
+			state = strings.TrimSpace(state)
+			switch state {
+			case StatusActive, StatusStandby, StatusPaused:
+				return state, nil
+			}
+			return "", fmt.Errorf("unknown ovn-northd status %q", state)
+		}
+	}
+	return "", fmt.Errorf("no status in %q", output)
+}
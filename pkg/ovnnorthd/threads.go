@@ -0,0 +1,50 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovnnorthd
+
+import (
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+)
+
+// NThreads - number of threads ovn-northd runs with. In auto mode one thread
+// per full CPU of the limit, or the request if no limit is set, bounded by
+// NThreadsMin and NThreadsMax.
+func NThreads(instance *ovnv1.OVNNorthd) int32 {
+	fixed := int32(1)
+	if instance.Spec.NThreads != nil {
+		fixed = *instance.Spec.NThreads
+	}
+	if instance.Spec.NThreadsMode != ovnv1.NThreadsModeAuto {
+		return fixed
+	}
+
+	threads := fixed
+	cpu := instance.Spec.Resources.Limits.Cpu()
+	if cpu.IsZero() {
+		cpu = instance.Spec.Resources.Requests.Cpu()
+	}
+	if !cpu.IsZero() {
+		threads = int32(cpu.MilliValue() / 1000)
+		if threads < 1 {
+			threads = 1
+		}
+	}
+
+	if instance.Spec.NThreadsMin != nil && threads < *instance.Spec.NThreadsMin {
+		threads = *instance.Spec.NThreadsMin
+	}
+	if instance.Spec.NThreadsMax != nil && threads > *instance.Spec.NThreadsMax {
+		threads = *instance.Spec.NThreadsMax
+	}
+	return threads
+}
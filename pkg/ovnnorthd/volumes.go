@@ -0,0 +1,61 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovnnorthd
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetScriptsVolume - volume of the ConfigMap holding the probe scripts
+func GetScriptsVolume(name string) corev1.Volume {
+	var scriptsVolumeDefaultMode int32 = 0755
+	return corev1.Volume{
+		Name: "scripts",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				DefaultMode: &scriptsVolumeDefaultMode,
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: name + "-scripts",
+				},
+			},
+		},
+	}
+}
+
+// GetScriptsVolumeMount - mount of the probe scripts
+func GetScriptsVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      "scripts",
+		MountPath: "/usr/local/bin/container-scripts",
+		ReadOnly:  true,
+	}
+}
+
+// GetRundirVolume - run directory shared between ovn-northd and the metrics
+// sidecar, holding the unixctl socket
+func GetRundirVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: "ovn-rundir",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+// GetRundirVolumeMount - mount of the run directory
+func GetRundirVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      "ovn-rundir",
+		MountPath: "/tmp",
+	}
+}
@@ -0,0 +1,363 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovsdb
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/model"
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ErrNotFound - the requested row does not exist
+var ErrNotFound = errors.New("row not found")
+
+// cacheSyncInterval - how often the client cache is checked for the changes
+// of a transaction
+const cacheSyncInterval = 50 * time.Millisecond
+
+// Client - typed access to the NB or SB database of an OVNDBCluster, it is
+// not safe for concurrent use
+type Client struct {
+	dbType string
+	ovs    libovsdbclient.Client
+	// chassisMonitored - whether the Chassis table is in the cache
+	chassisMonitored bool
+}
+
+// NewClient - connects to the NB or SB database and monitors the NB_Global or
+// SB_Global and the Connection table, other tables are only monitored by the
+// methods which need them. endpoint is the comma separated list of the
+// cluster members as returned by GetInternalEndpoint(), tlsConfig is required
+// for ssl: endpoints. Followers forward the transactions to the leader, so any
+// member is used. The client logs to logger.
+func NewClient(
+	ctx context.Context,
+	logger logr.Logger,
+	dbType string,
+	endpoint string,
+	tlsConfig *tls.Config,
+) (*Client, error) {
+	dbModel, err := ClientDBModel(dbType)
+	if err != nil {
+		return nil, err
+	}
+
+	// without a logger libovsdb sets up its own, changing the verbosity of
+	// the global stdr logger
+	opts := []libovsdbclient.Option{libovsdbclient.WithLogger(&logger)}
+	endpoints := 0
+	for _, ep := range strings.Split(endpoint, ",") {
+		ep = strings.TrimSpace(ep)
+		if ep == "" {
+			continue
+		}
+		opts = append(opts, libovsdbclient.WithEndpoint(ep))
+		endpoints++
+	}
+	if endpoints == 0 {
+		return nil, fmt.Errorf("no %s database endpoint", dbType)
+	}
+	if tlsConfig != nil {
+		opts = append(opts, libovsdbclient.WithTLSConfig(tlsConfig))
+	}
+
+	ovs, err := libovsdbclient.NewOVSDBClient(dbModel, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := ovs.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("error connecting to the %s database %s: %w", dbType, endpoint, err)
+	}
+	var global model.Model = &NBGlobal{}
+	if dbType == ovnv1.SBDBType {
+		global = &SBGlobal{}
+	}
+	monitor := ovs.NewMonitor(
+		libovsdbclient.WithTable(global),
+		libovsdbclient.WithTable(&Connection{}),
+	)
+	if _, err := ovs.Monitor(ctx, monitor); err != nil {
+		ovs.Close()
+		return nil, fmt.Errorf("error monitoring the %s database: %w", dbType, err)
+	}
+
+	return &Client{dbType: dbType, ovs: ovs}, nil
+}
+
+// Close - closes the connection to the database
+func (c *Client) Close() {
+	c.ovs.Close()
+}
+
+// NBGlobal - returns the NB_Global row
+func (c *Client) NBGlobal(ctx context.Context) (*NBGlobal, error) {
+	if c.dbType != ovnv1.NBDBType {
+		return nil, fmt.Errorf("%s is not in the %s database", NBGlobalTable, c.dbType)
+	}
+	rows := []NBGlobal{}
+	if err := c.ovs.List(ctx, &rows); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s: %w", NBGlobalTable, ErrNotFound)
+	}
+	return &rows[0], nil
+}
+
+// SBGlobal - returns the SB_Global row
+func (c *Client) SBGlobal(ctx context.Context) (*SBGlobal, error) {
+	if c.dbType != ovnv1.SBDBType {
+		return nil, fmt.Errorf("%s is not in the %s database", SBGlobalTable, c.dbType)
+	}
+	rows := []SBGlobal{}
+	if err := c.ovs.List(ctx, &rows); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s: %w", SBGlobalTable, ErrNotFound)
+	}
+	return &rows[0], nil
+}
+
+// global - returns the NB_Global or SB_Global row together with pointers to
+// its options and connections columns, as required to build mutations
+func (c *Client) global(ctx context.Context) (model.Model, *map[string]string, *[]string, error) {
+	if c.dbType == ovnv1.NBDBType {
+		g, err := c.NBGlobal(ctx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return g, &g.Options, &g.Connections, nil
+	}
+	g, err := c.SBGlobal(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return g, &g.Options, &g.Connections, nil
+}
+
+// GlobalOptions - returns the options of the NB_Global or SB_Global row
+func (c *Client) GlobalOptions(ctx context.Context) (map[string]string, error) {
+	_, options, _, err := c.global(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return *options, nil
+}
+
+// SetGlobalOptions - sets and removes options of the NB_Global or SB_Global
+// row in a single transaction. Options which are not mentioned are kept. It
+// returns once the changes are in the client cache.
+func (c *Client) SetGlobalOptions(ctx context.Context, set map[string]string, remove []string) error {
+	if len(set) == 0 && len(remove) == 0 {
+		return nil
+	}
+	g, options, _, err := c.global(ctx)
+	if err != nil {
+		return err
+	}
+
+	// insert does not overwrite existing keys, so the keys which are set are
+	// deleted first
+	keys := append([]string{}, remove...)
+	for key := range set {
+		keys = append(keys, key)
+	}
+	mutations := []model.Mutation{{
+		Field:   options,
+		Mutator: ovsdb.MutateOperationDelete,
+		Value:   keys,
+	}}
+	if len(set) > 0 {
+		mutations = append(mutations, model.Mutation{
+			Field:   options,
+			Mutator: ovsdb.MutateOperationInsert,
+			Value:   set,
+		})
+	}
+
+	ops, err := c.ovs.Where(g).Mutate(g, mutations...)
+	if err != nil {
+		return err
+	}
+	if err := c.transact(ctx, ops); err != nil {
+		return err
+	}
+
+	// the monitor update of the transaction may arrive after its reply, wait
+	// for it so the options read back afterwards include the changes
+	return wait.PollUntilContextCancel(ctx, cacheSyncInterval, true, func(ctx context.Context) (bool, error) {
+		options, err := c.GlobalOptions(ctx)
+		if err != nil {
+			return false, err
+		}
+		for key, value := range set {
+			if current, ok := options[key]; !ok || current != value {
+				return false, nil
+			}
+		}
+		for _, key := range remove {
+			if _, ok := options[key]; ok {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// Connections - returns the rows of the Connection table
+func (c *Client) Connections(ctx context.Context) ([]Connection, error) {
+	rows := []Connection{}
+	if err := c.ovs.List(ctx, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Connection - returns the row of the Connection table with the given target
+func (c *Client) Connection(ctx context.Context, target string) (*Connection, error) {
+	conn := &Connection{Target: target}
+	if err := c.ovs.Get(ctx, conn); err != nil {
+		if errors.Is(err, libovsdbclient.ErrNotFound) {
+			return nil, fmt.Errorf("%s %s: %w", ConnectionTable, target, ErrNotFound)
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+// EnsureConnection - creates the connection and references it from the
+// NB_Global or SB_Global row, or updates the settings of an existing
+// connection with the same target. The ephemeral columns are ignored.
+func (c *Client) EnsureConnection(ctx context.Context, conn Connection) error {
+	current, err := c.Connection(ctx, conn.Target)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	var ops []ovsdb.Operation
+	if current != nil {
+		conn.UUID = current.UUID
+		ops, err = c.ovs.Where(&conn).Update(&conn,
+			&conn.MaxBackoff, &conn.InactivityProbe, &conn.OtherConfig, &conn.ExternalIDs)
+		if err != nil {
+			return err
+		}
+	} else {
+		g, _, connections, err := c.global(ctx)
+		if err != nil {
+			return err
+		}
+		conn.UUID = "newconnection"
+		ops, err = c.ovs.Create(&conn)
+		if err != nil {
+			return err
+		}
+		mutateOps, err := c.ovs.Where(g).Mutate(g, model.Mutation{
+			Field:   connections,
+			Mutator: ovsdb.MutateOperationInsert,
+			Value:   []string{conn.UUID},
+		})
+		if err != nil {
+			return err
+		}
+		ops = append(ops, mutateOps...)
+	}
+	return c.transact(ctx, ops)
+}
+
+// DeleteConnection - removes the connection with the given target. It is a
+// no-op if there is none.
+func (c *Client) DeleteConnection(ctx context.Context, target string) error {
+	conn, err := c.Connection(ctx, target)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	g, _, connections, err := c.global(ctx)
+	if err != nil {
+		return err
+	}
+
+	ops, err := c.ovs.Where(g).Mutate(g, model.Mutation{
+		Field:   connections,
+		Mutator: ovsdb.MutateOperationDelete,
+		Value:   []string{conn.UUID},
+	})
+	if err != nil {
+		return err
+	}
+	deleteOps, err := c.ovs.Where(conn).Delete()
+	if err != nil {
+		return err
+	}
+	return c.transact(ctx, append(ops, deleteOps...))
+}
+
+// Chassis - returns the rows of the Chassis table. The table can be large, it
+// is only monitored once it is used.
+func (c *Client) Chassis(ctx context.Context) ([]Chassis, error) {
+	if c.dbType != ovnv1.SBDBType {
+		return nil, fmt.Errorf("%s is not in the %s database", ChassisTable, c.dbType)
+	}
+	if !c.chassisMonitored {
+		if _, err := c.ovs.Monitor(ctx, c.ovs.NewMonitor(libovsdbclient.WithTable(&Chassis{}))); err != nil {
+			return nil, fmt.Errorf("error monitoring the %s table: %w", ChassisTable, err)
+		}
+		c.chassisMonitored = true
+	}
+	rows := []Chassis{}
+	if err := c.ovs.List(ctx, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ChassisByHostname - returns the chassis registered by the given node
+func (c *Client) ChassisByHostname(ctx context.Context, hostname string) (*Chassis, error) {
+	rows, err := c.Chassis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		if rows[i].Hostname == hostname {
+			return &rows[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s %s: %w", ChassisTable, hostname, ErrNotFound)
+}
+
+// transact - runs the operations in a single transaction and checks the
+// result of each of them
+func (c *Client) transact(ctx context.Context, ops []ovsdb.Operation) error {
+	results, err := c.ovs.Transact(ctx, ops...)
+	if err != nil {
+		return err
+	}
+	opErrs, err := ovsdb.CheckOperationResults(results, ops)
+	if err != nil {
+		return fmt.Errorf("%w: %v", err, opErrs)
+	}
+	return nil
+}
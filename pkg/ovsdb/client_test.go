@@ -0,0 +1,270 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovsdb
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	"github.com/go-logr/logr"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/database"
+	"github.com/ovn-org/libovsdb/model"
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/ovn-org/libovsdb/server"
+	"k8s.io/utils/ptr"
+)
+
+// startServer - starts an in-memory ovsdb-server serving the trimmed schema
+// of the given database type on a unix socket, seeded with the given rows
+func startServer(t *testing.T, dbType string, schemaFile string, rows ...model.Model) string {
+	g := NewWithT(t)
+
+	data, err := os.ReadFile(filepath.Join("testdata", schemaFile))
+	g.Expect(err).ToNot(HaveOccurred())
+	var schema ovsdb.DatabaseSchema
+	g.Expect(json.Unmarshal(data, &schema)).To(Succeed())
+
+	clientModel, err := ClientDBModel(dbType)
+	g.Expect(err).ToNot(HaveOccurred())
+	dbModel, errs := model.NewDatabaseModel(schema, clientModel)
+	g.Expect(errs).To(BeEmpty())
+
+	db := database.NewInMemoryDatabase(map[string]model.ClientDBModel{schema.Name: clientModel})
+	srv, err := server.NewOvsdbServer(db, dbModel)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	sock := filepath.Join(t.TempDir(), "db.sock")
+	go func() {
+		_ = srv.Serve("unix", sock)
+	}()
+	t.Cleanup(srv.Close)
+	g.Eventually(srv.Ready, 5*time.Second, 10*time.Millisecond).Should(BeTrue())
+
+	endpoint := "unix:" + sock
+	if len(rows) > 0 {
+		seed, err := libovsdbclient.NewOVSDBClient(clientModel, libovsdbclient.WithEndpoint(endpoint))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(seed.Connect(context.Background())).To(Succeed())
+		defer seed.Close()
+		ops, err := seed.Create(rows...)
+		g.Expect(err).ToNot(HaveOccurred())
+		results, err := seed.Transact(context.Background(), ops...)
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = ovsdb.CheckOperationResults(results, ops)
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+	return endpoint
+}
+
+func newTestClient(t *testing.T, dbType string, endpoint string) *Client {
+	g := NewWithT(t)
+	c, err := NewClient(context.Background(), logr.Discard(), dbType, endpoint, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestNewClientUnknownDBType(t *testing.T) {
+	g := NewWithT(t)
+	_, err := NewClient(context.Background(), logr.Discard(), "XX", "unix:/nonexistent", nil)
+	g.Expect(err).To(MatchError(ContainSubstring("unknown database type")))
+}
+
+func TestNewClientNoEndpoint(t *testing.T) {
+	g := NewWithT(t)
+	_, err := NewClient(context.Background(), logr.Discard(), ovnv1.NBDBType, " , ", nil)
+	g.Expect(err).To(MatchError(ContainSubstring("no NB database endpoint")))
+}
+
+func TestNBGlobalOptions(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	endpoint := startServer(t, ovnv1.NBDBType, "ovn-nb.ovsschema", &NBGlobal{
+		UUID:    "global",
+		Options: map[string]string{"northd_probe_interval": "5000", "mac_prefix": "0a:00:00"},
+	})
+	c := newTestClient(t, ovnv1.NBDBType, endpoint)
+
+	options, err := c.GlobalOptions(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(options).To(Equal(map[string]string{"northd_probe_interval": "5000", "mac_prefix": "0a:00:00"}))
+
+	// the changes are in the cache once SetGlobalOptions returns, values are
+	// passed as is without any quoting
+	g.Expect(c.SetGlobalOptions(ctx,
+		map[string]string{"northd_probe_interval": "10000", "foo=bar": `a "b" \ c`},
+		[]string{"mac_prefix"})).To(Succeed())
+	options, err = c.GlobalOptions(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(options).To(Equal(map[string]string{
+		"northd_probe_interval": "10000",
+		"foo=bar":               `a "b" \ c`,
+	}))
+
+	// removing options which are not set is a no-op
+	g.Expect(c.SetGlobalOptions(ctx, nil, []string{"mac_prefix", "foo=bar"})).To(Succeed())
+	options, err = c.GlobalOptions(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(options).To(Equal(map[string]string{"northd_probe_interval": "10000"}))
+
+	// the SB tables are not available on the NB database
+	_, err = c.SBGlobal(ctx)
+	g.Expect(err).To(HaveOccurred())
+	_, err = c.Chassis(ctx)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestGlobalNotFound(t *testing.T) {
+	g := NewWithT(t)
+	endpoint := startServer(t, ovnv1.SBDBType, "ovn-sb.ovsschema")
+	c := newTestClient(t, ovnv1.SBDBType, endpoint)
+
+	_, err := c.SBGlobal(context.Background())
+	g.Expect(err).To(MatchError(ErrNotFound))
+}
+
+func TestConnections(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	endpoint := startServer(t, ovnv1.SBDBType, "ovn-sb.ovsschema", &SBGlobal{UUID: "global"})
+	c := newTestClient(t, ovnv1.SBDBType, endpoint)
+
+	g.Expect(c.EnsureConnection(ctx, Connection{
+		Target:          "pssl:6642:[::]",
+		InactivityProbe: ptr.To(60000),
+	})).To(Succeed())
+	g.Eventually(func(g Gomega) {
+		conn, err := c.Connection(ctx, "pssl:6642:[::]")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conn.InactivityProbe).To(Equal(ptr.To(60000)))
+
+		global, err := c.SBGlobal(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(global.Connections).To(ConsistOf(conn.UUID))
+	}, 5*time.Second, 10*time.Millisecond).Should(Succeed())
+
+	// an existing connection is updated in place
+	g.Expect(c.EnsureConnection(ctx, Connection{
+		Target:          "pssl:6642:[::]",
+		InactivityProbe: ptr.To(180000),
+		MaxBackoff:      ptr.To(8000),
+	})).To(Succeed())
+	g.Eventually(func(g Gomega) {
+		conns, err := c.Connections(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conns).To(HaveLen(1))
+		g.Expect(conns[0].InactivityProbe).To(Equal(ptr.To(180000)))
+		g.Expect(conns[0].MaxBackoff).To(Equal(ptr.To(8000)))
+	}, 5*time.Second, 10*time.Millisecond).Should(Succeed())
+
+	g.Expect(c.DeleteConnection(ctx, "pssl:6642:[::]")).To(Succeed())
+	g.Eventually(func(g Gomega) {
+		conns, err := c.Connections(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conns).To(BeEmpty())
+
+		global, err := c.SBGlobal(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(global.Connections).To(BeEmpty())
+	}, 5*time.Second, 10*time.Millisecond).Should(Succeed())
+
+	// deleting a missing connection is a no-op
+	g.Expect(c.DeleteConnection(ctx, "pssl:6642:[::]")).To(Succeed())
+}
+
+func TestChassis(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	endpoint := startServer(t, ovnv1.SBDBType, "ovn-sb.ovsschema",
+		&SBGlobal{UUID: "global"},
+		&Chassis{
+			UUID:        "chassis0",
+			Name:        "4a9c7bce-0b28-4b3b-a5e5-6bb3a9e1e1f0",
+			Hostname:    "compute-0",
+			ExternalIDs: map[string]string{"ovn-bridge-mappings": "datacentre:br-ex"},
+		},
+		&Chassis{
+			UUID:     "chassis1",
+			Name:     "0f6a7f52-53c8-4b6d-9f3b-3cbd4c7a6e3e",
+			Hostname: "compute-1",
+		},
+	)
+	c := newTestClient(t, ovnv1.SBDBType, endpoint)
+
+	// the Chassis table is not monitored until it is used
+	rows := []Chassis{}
+	g.Expect(c.ovs.List(ctx, &rows)).To(Succeed())
+	g.Expect(rows).To(BeEmpty())
+
+	chassis, err := c.Chassis(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(chassis).To(HaveLen(2))
+
+	ch, err := c.ChassisByHostname(ctx, "compute-0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ch.ExternalIDs).To(HaveKeyWithValue("ovn-bridge-mappings", "datacentre:br-ex"))
+
+	_, err = c.ChassisByHostname(ctx, "compute-2")
+	g.Expect(err).To(MatchError(ErrNotFound))
+}
+
+func TestTLSConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ToNot(HaveOccurred())
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ovn"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	g.Expect(err).ToNot(HaveOccurred())
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	g.Expect(err).ToNot(HaveOccurred())
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	cfg, err := TLSConfig(certPEM, keyPEM, certPEM)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cfg.Certificates).To(HaveLen(1))
+	g.Expect(cfg.RootCAs).ToNot(BeNil())
+
+	// the client certificate is optional
+	cfg, err = TLSConfig(nil, nil, certPEM)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cfg.Certificates).To(BeEmpty())
+
+	_, err = TLSConfig(certPEM, keyPEM, nil)
+	g.Expect(err).To(HaveOccurred())
+	_, err = TLSConfig(certPEM, nil, certPEM)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,100 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovsdb
+
+import (
+	"fmt"
+
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"github.com/ovn-org/libovsdb/model"
+)
+
+const (
+	// NBDatabase - name of the OVN northbound database
+	NBDatabase = "OVN_Northbound"
+	// SBDatabase - name of the OVN southbound database
+	SBDatabase = "OVN_Southbound"
+
+	// NBGlobalTable - NB_Global table
+	NBGlobalTable = "NB_Global"
+	// SBGlobalTable - SB_Global table
+	SBGlobalTable = "SB_Global"
+	// ConnectionTable - Connection table, present in both databases
+	ConnectionTable = "Connection"
+	// ChassisTable - Chassis table of the southbound database
+	ChassisTable = "Chassis"
+)
+
+// Connection - row of the Connection table. Only the columns shared by the
+// northbound and southbound schemas are mapped.
+type Connection struct {
+	UUID            string            `ovsdb:"_uuid"`
+	Target          string            `ovsdb:"target"`
+	MaxBackoff      *int              `ovsdb:"max_backoff"`
+	InactivityProbe *int              `ovsdb:"inactivity_probe"`
+	OtherConfig     map[string]string `ovsdb:"other_config"`
+	ExternalIDs     map[string]string `ovsdb:"external_ids"`
+	IsConnected     bool              `ovsdb:"is_connected"`
+	Status          map[string]string `ovsdb:"status"`
+}
+
+// NBGlobal - the single row of the NB_Global table
+type NBGlobal struct {
+	UUID        string            `ovsdb:"_uuid"`
+	Name        string            `ovsdb:"name"`
+	NbCfg       int               `ovsdb:"nb_cfg"`
+	SbCfg       int               `ovsdb:"sb_cfg"`
+	HvCfg       int               `ovsdb:"hv_cfg"`
+	Options     map[string]string `ovsdb:"options"`
+	ExternalIDs map[string]string `ovsdb:"external_ids"`
+	Connections []string          `ovsdb:"connections"`
+}
+
+// SBGlobal - the single row of the SB_Global table
+type SBGlobal struct {
+	UUID        string            `ovsdb:"_uuid"`
+	NbCfg       int               `ovsdb:"nb_cfg"`
+	Options     map[string]string `ovsdb:"options"`
+	ExternalIDs map[string]string `ovsdb:"external_ids"`
+	Connections []string          `ovsdb:"connections"`
+}
+
+// Chassis - row of the Chassis table, owned by ovn-controller
+type Chassis struct {
+	UUID           string            `ovsdb:"_uuid"`
+	Name           string            `ovsdb:"name"`
+	Hostname       string            `ovsdb:"hostname"`
+	NbCfg          int               `ovsdb:"nb_cfg"`
+	ExternalIDs    map[string]string `ovsdb:"external_ids"`
+	OtherConfig    map[string]string `ovsdb:"other_config"`
+	TransportZones []string          `ovsdb:"transport_zones"`
+}
+
+// ClientDBModel - returns the client model of the NB or SB database. Tables
+// and columns which are not mapped are ignored by the client.
+func ClientDBModel(dbType string) (model.ClientDBModel, error) {
+	switch dbType {
+	case ovnv1.NBDBType:
+		return model.NewClientDBModel(NBDatabase, map[string]model.Model{
+			NBGlobalTable:   &NBGlobal{},
+			ConnectionTable: &Connection{},
+		})
+	case ovnv1.SBDBType:
+		return model.NewClientDBModel(SBDatabase, map[string]model.Model{
+			SBGlobalTable:   &SBGlobal{},
+			ConnectionTable: &Connection{},
+			ChassisTable:    &Chassis{},
+		})
+	}
+	return model.ClientDBModel{}, fmt.Errorf("unknown database type %q", dbType)
+}
@@ -0,0 +1,80 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovsdb
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	lib_tls "github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TLSConfig - builds the client TLS configuration from PEM encoded material.
+// The client certificate is optional, the CA bundle is required.
+func TLSConfig(certPEM []byte, keyPEM []byte, caPEM []byte) (*tls.Config, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no CA certificate found in the CA bundle")
+	}
+	cfg := &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	if len(certPEM) > 0 || len(keyPEM) > 0 {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("error loading the client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// TLSConfigFromSecrets - builds the client TLS configuration from the same
+// secrets which are mounted into the database pods: the service cert secret
+// (tls.crt/tls.key/ca.crt) and the optional CA bundle secret
+// (tls-ca-bundle.pem).
+func TLSConfigFromSecrets(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	certSecretName string,
+	caBundleSecretName string,
+) (*tls.Config, error) {
+	certSecret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Name: certSecretName, Namespace: namespace}, certSecret)
+	if err != nil {
+		return nil, err
+	}
+	caPEM := append([]byte{}, certSecret.Data[lib_tls.CAKey]...)
+
+	if caBundleSecretName != "" {
+		caSecret := &corev1.Secret{}
+		err = c.Get(ctx, types.NamespacedName{Name: caBundleSecretName, Namespace: namespace}, caSecret)
+		if err != nil {
+			return nil, err
+		}
+		bundle, ok := caSecret.Data[lib_tls.CABundleKey]
+		if !ok {
+			return nil, fmt.Errorf("%s not found in secret %s", lib_tls.CABundleKey, caBundleSecretName)
+		}
+		caPEM = append(append(caPEM, '\n'), bundle...)
+	}
+
+	return TLSConfig(certSecret.Data[lib_tls.CertKey], certSecret.Data[lib_tls.PrivateKey], caPEM)
+}
@@ -0,0 +1,138 @@
+#!/bin/bash
+#
+# Copyright 2024 Red Hat Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"); you may
+# not use this file except in compliance with the License. You may obtain
+# a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+# WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+# License for the specific language governing permissions and limitations
+# under the License.
+
+# Applies the node configuration rendered by the operator whenever it changes
+# and reports the hash of the applied configuration in an annotation of the pod.
+# In between the external-ids are compared with the configuration, the keys
+# which drifted are reported in an annotation of the pod and re-applied unless
+# the DriftRemediation of the configuration is Manual.
+# The system-id, encap IP and bridge mappings of the chassis and the errors of
+# applying the configuration are reported in annotations of the pod as well.
+source $(dirname $0)/functions
+
+NodeConfig=${NodeConfig:-"/var/lib/ovn-config/config"}
+ConfigHashAnnotation=${ConfigHashAnnotation:-"ovn.openstack.org/config-hash"}
+DriftAnnotation=${DriftAnnotation:-"ovn.openstack.org/external-ids-drift"}
+RemediatedAnnotation=${RemediatedAnnotation:-"ovn.openstack.org/external-ids-remediated"}
+ConfigAppliedAnnotation=${ConfigAppliedAnnotation:-"ovn.openstack.org/config-applied"}
+ConfigErrorAnnotation=${ConfigErrorAnnotation:-"ovn.openstack.org/config-error"}
+SystemIDAnnotation=${SystemIDAnnotation:-"ovn.openstack.org/system-id"}
+EncapIPAnnotation=${EncapIPAnnotation:-"ovn.openstack.org/encap-ip"}
+BridgeMappingsAnnotation=${BridgeMappingsAnnotation:-"ovn.openstack.org/bridge-mappings"}
+ConfigCheckInterval=${ConfigCheckInterval:-10}
+SA_DIR=/var/run/secrets/kubernetes.io/serviceaccount
+ConfigLog=$(mktemp)
+
+# Reads the KEY=VALUE lines of the node configuration, values are never evaluated
+function load_node_config {
+    local key value
+    ConfigHash=""
+    DriftRemediation="Automatic"
+    ExtraExternalIDs=""
+    OVSOtherConfig=""
+    while IFS='=' read -r key value; do
+        case "$key" in
+            OVNBridge|OVNRemote|OVNEncapType|OVNAvailabilityZones|EnableChassisAsGateway|OVNRemoteProbeInterval|PhysicalNetworks|ExtraExternalIDs|OVSOtherConfig|DriftRemediation|ConfigHash)
+                printf -v "$key" '%s' "$value"
+                ;;
+        esac
+    done < ${NodeConfig}
+}
+
+# Merges the given JSON annotations into the annotations of the pod
+function annotate_pod {
+    curl -sSf --cacert ${SA_DIR}/ca.crt \
+        -H "Authorization: Bearer $(cat ${SA_DIR}/token)" \
+        -H "Content-Type: application/merge-patch+json" \
+        -X PATCH \
+        -d "{\"metadata\":{\"annotations\":{$1}}}" \
+        "https://${KUBERNETES_SERVICE_HOST}:${KUBERNETES_SERVICE_PORT}/api/v1/namespaces/${PodNamespace}/pods/${PodName}" > /dev/null
+}
+
+# Sets the drift annotation of the pod to the drifted keys, removes it if
+# there are none
+function report_drift {
+    if [ -n "$1" ]; then
+        annotate_pod "\"${DriftAnnotation}\":\"$1\""
+    else
+        annotate_pod "\"${DriftAnnotation}\":null"
+    fi
+}
+
+# Prints the annotations with the state of the chassis
+function chassis_state {
+    echo "\"${SystemIDAnnotation}\":\"$(get_external_id system-id)\",\"${EncapIPAnnotation}\":\"$(get_external_id ovn-encap-ip)\",\"${BridgeMappingsAnnotation}\":\"$(get_external_id ovn-bridge-mappings)\""
+}
+
+# Applies the node configuration, the output is kept in ConfigLog
+function apply_node_config {
+    (set -ex; configure_external_ids; configure_physical_networks; configure_extra_config) > ${ConfigLog} 2>&1
+    local rc=$?
+    cat ${ConfigLog}
+    return $rc
+}
+
+# Prints the last error of applying the node configuration, escaped for JSON
+function config_error {
+    local error=$(grep -v '^+' ${ConfigLog} | tail -n1)
+    error=${error:-"failed to apply node configuration ${ConfigHash}"}
+    error=${error//\\/\\\\}
+    echo "${error//\"/\\\"}"
+}
+
+function now {
+    date -u +%Y-%m-%dT%H:%M:%SZ
+}
+
+wait_for_ovsdb_server
+
+applied=""
+reported=""
+reported_state=""
+while true; do
+    if [ -s ${NodeConfig} ]; then
+        load_node_config
+        if [ -n "$ConfigHash" ] && [ "$ConfigHash" != "$applied" ]; then
+            echo "Applying node configuration ${ConfigHash}"
+            if ! apply_node_config; then
+                echo "Failed to apply node configuration ${ConfigHash}, retrying"
+                annotate_pod "\"${ConfigErrorAnnotation}\":\"$(config_error)\"" || true
+            elif annotate_pod "\"${ConfigHashAnnotation}\":\"${ConfigHash}\",\"${ConfigAppliedAnnotation}\":\"$(now)\",\"${ConfigErrorAnnotation}\":null"; then
+                applied=$ConfigHash
+            fi
+        fi
+        if [ -n "$applied" ] && [ "$ConfigHash" == "$applied" ]; then
+            drift=$(external_ids_drift)
+            if [ -n "$drift" ] && [ "$DriftRemediation" != "Manual" ]; then
+                echo "external-ids ${drift} drifted from the node configuration, re-applying it"
+                if apply_node_config; then
+                    annotate_pod "\"${RemediatedAnnotation}\":\"$(now)\"" || true
+                    drift=$(external_ids_drift)
+                fi
+            fi
+            if [ "$drift" != "$reported" ] && report_drift "$drift"; then
+                reported=$drift
+            fi
+        fi
+        state=$(chassis_state)
+        if [ "$state" != "$reported_state" ] && annotate_pod "$state"; then
+            reported_state=$state
+        fi
+    else
+        echo "${NodeConfig} does not exist yet. Waiting..."
+    fi
+    sleep ${ConfigCheckInterval}
+done
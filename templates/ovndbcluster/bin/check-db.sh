@@ -0,0 +1,64 @@
+#!/usr/bin/env bash
+#
+# Copyright 2024 Red Hat Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"); you may
+# not use this file except in compliance with the License. You may obtain
+# a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+# WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+# License for the specific language governing permissions and limitations
+# under the License.
+
+# Validates the local database file and reports the state of the local
+# replica so the operator can compare the members of the cluster. Output is
+# one key=value pair per line.
+source $(dirname $0)/functions
+
+DB_NAME="OVN_Northbound"
+if [[ "${DB_TYPE}" == "sb" ]]; then
+    DB_NAME="OVN_Southbound"
+fi
+
+# Standalone databases are validated by reading all of their records.
+if [[ "${MODE}" == "clustered" ]]; then
+    OUTPUT=$(ovsdb-tool check-cluster ${DB_FILE} 2>&1)
+else
+    OUTPUT=$(ovsdb-tool show-log ${DB_FILE} 2>&1 >/dev/null)
+fi
+if [ $? -ne 0 ]; then
+    echo "valid=false"
+    echo "error=$(echo ${OUTPUT} | tr '\n' ' ')"
+    exit 0
+fi
+echo "valid=true"
+
+# Only the members of a cluster apply the same log, in active-backup mode the
+# backup can't be compared to the active member.
+if [[ "${MODE}" != "clustered" ]]; then
+    exit 0
+fi
+
+# The applied index is the last index in the raft log minus the entries that
+# were not yet applied to the database.
+function applied_index() {
+    local status last_index not_applied
+    status=$(ovs-appctl -t /tmp/ovn${DB_TYPE}_db.ctl cluster/status ${DB_NAME})
+    last_index=$(echo "${status}" | awk -F'[][, ]+' '/^Log:/ {print $3}')
+    not_applied=$(echo "${status}" | awk -F': ' '/^Entries not yet applied:/ {print $2}')
+    echo $((last_index - ${not_applied:-0}))
+}
+
+# Checksum of the database contents as served by the local replica. Members
+# with the same applied index must report the same checksum. The checksum is
+# only reported if no transaction was applied while dumping the database.
+INDEX=$(applied_index)
+CHECKSUM=$(ovsdb-client dump unix:/tmp/ovn${DB_TYPE}_db.sock ${DB_NAME} | sha256sum | cut -d' ' -f1)
+echo "applied_index=${INDEX}"
+if [ "$(applied_index)" == "${INDEX}" ]; then
+    echo "checksum=${CHECKSUM}"
+fi
@@ -23,7 +23,7 @@ fi
 
 # There is nothing special about -0 pod, except that it's always guaranteed to
 # exist, assuming any replicas are ordered.
-if [[ "$(hostname)" != "{{ .SERVICE_NAME }}-0" ]]; then
+if [[ "${MODE}" == "clustered" && "$(hostname)" != "{{ .SERVICE_NAME }}-0" ]]; then
     ovs-appctl -t /tmp/ovn${DB_TYPE}_db.ctl cluster/leave ${DB_NAME}
 
     # wait for when the leader confirms we left the cluster
@@ -40,7 +40,7 @@ fi
 # If replicas are 0 and *all* pods are removed, we still want to retain the
 # database with its cid/sid for when the cluster is scaled back to > 0, so
 # leaving the database file intact for -0 pod.
-if [[ "$(hostname)" != "{{ .SERVICE_NAME }}-0" ]]; then
+if [[ "${MODE}" == "clustered" && "$(hostname)" != "{{ .SERVICE_NAME }}-0" ]]; then
     # now that we left, the database file is no longer valid
     cleanup_db_file
 fi
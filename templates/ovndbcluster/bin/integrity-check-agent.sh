@@ -0,0 +1,59 @@
+#!/usr/bin/env bash
+#
+# Copyright 2024 Red Hat Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"); you may
+# not use this file except in compliance with the License. You may obtain
+# a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+# WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+# License for the specific language governing permissions and limitations
+# under the License.
+
+# Checks the local member every interval and reports the output of check-db.sh
+# and the time of the check in annotations of the pod, the operator compares
+# the results of the members of the cluster.
+source $(dirname $0)/functions
+
+IntegrityCheckInterval=${IntegrityCheckInterval:-3600}
+IntegrityCheckAnnotation=${IntegrityCheckAnnotation:-"ovn.openstack.org/integrity-check"}
+IntegrityCheckTimeAnnotation=${IntegrityCheckTimeAnnotation:-"ovn.openstack.org/integrity-check-time"}
+SA_DIR=/var/run/secrets/kubernetes.io/serviceaccount
+
+# Merges the given JSON annotations into the annotations of the pod
+function annotate_pod {
+    curl -sSf --cacert ${SA_DIR}/ca.crt \
+        -H "Authorization: Bearer $(cat ${SA_DIR}/token)" \
+        -H "Content-Type: application/merge-patch+json" \
+        -X PATCH \
+        -d "{\"metadata\":{\"annotations\":{$1}}}" \
+        "https://${KUBERNETES_SERVICE_HOST}:${KUBERNETES_SERVICE_PORT}/api/v1/namespaces/${PodNamespace}/pods/${PodName}" > /dev/null
+}
+
+# Escapes the given lines for a JSON string
+function json_escape {
+    local value=${1//\\/\\\\}
+    value=${value//\"/\\\"}
+    value=${value//$'\t'/ }
+    echo "${value//$'\n'/\\n}"
+}
+
+function now {
+    date -u +%Y-%m-%dT%H:%M:%SZ
+}
+
+wait_for_ovsdb_server
+
+while true; do
+    result=$($(dirname $0)/check-db.sh 2>&1)
+    echo "${result}"
+    until annotate_pod "\"${IntegrityCheckAnnotation}\":\"$(json_escape "${result}")\",\"${IntegrityCheckTimeAnnotation}\":\"$(now)\""; do
+        echo "Failed to report the integrity check result, retrying"
+        sleep 10
+    done
+    sleep ${IntegrityCheckInterval}
+done
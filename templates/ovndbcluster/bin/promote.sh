@@ -1,6 +1,6 @@
-#!/bin//bash
+#!/usr/bin/env bash
 #
-# Copyright 2022 Red Hat Inc.
+# Copyright 2024 Red Hat Inc.
 #
 # Licensed under the Apache License, Version 2.0 (the "License"); you may
 # not use this file except in compliance with the License. You may obtain
@@ -13,13 +13,10 @@
 # WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
 # License for the specific language governing permissions and limitations
 # under the License.
-
-source $(dirname $0)/functions
-
-wait_for_ovsdb_server
-
-# From now on, we should exit immediatelly when any command exits with non-zero status
 set -ex
+source $(dirname $0)/functions
 
-configure_external_ids
-configure_physical_networks
+# Promote the local backup ovsdb-server to active in active-backup mode. It
+# stops replicating from the former active member and starts accepting writes.
+ovs-appctl -t /tmp/ovn${DB_TYPE}_db.ctl ovsdb-server/disconnect-active-ovsdb-server
+ovs-appctl -t /tmp/ovn${DB_TYPE}_db.ctl ovsdb-server/sync-status
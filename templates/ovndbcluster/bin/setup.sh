@@ -40,12 +40,13 @@ else
 fi
 
 # The --cluster-remote-addr / --cluster-local-addr options will have effect
-# only on bootstrap, when we assume the leadership role for the first pod.
+# only on bootstrap, when we assume the leadership role for the first pod, or
+# for the former active member when converting to clustered mode.
 # Later, cli arguments are still passed, but raft membership hints are already
 # stored in the databases, and hence the arguments are of no effect.
-if [[ "$(hostname)" != "{{ .SERVICE_NAME }}-0" ]]; then
-    #ovsdb-tool join-cluster /etc/ovn/ovn${DB_TYPE}_db.db ${DB_NAME} tcp:$(hostname).{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local:${RAFT_PORT} tcp:{{ .SERVICE_NAME }}-0.{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local:${RAFT_PORT}
-    OPTS="--db-${DB_TYPE}-cluster-remote-addr={{ .SERVICE_NAME }}-0.{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local --db-${DB_TYPE}-cluster-remote-port=${RAFT_PORT}"
+if [[ "${MODE}" == "clustered" && "$(hostname)" != "${ACTIVE_MEMBER}" ]]; then
+    #ovsdb-tool join-cluster /etc/ovn/ovn${DB_TYPE}_db.db ${DB_NAME} tcp:$(hostname).{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local:${RAFT_PORT} tcp:${ACTIVE_MEMBER}.{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local:${RAFT_PORT}
+    OPTS="--db-${DB_TYPE}-cluster-remote-addr=${ACTIVE_MEMBER}.{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local --db-${DB_TYPE}-cluster-remote-port=${RAFT_PORT}"
 fi
 
 
@@ -53,9 +54,11 @@ fi
 # extra_args after --
 set /usr/share/ovn/scripts/ovn-ctl --no-monitor
 
+{{ if eq .MODE "clustered" -}}
 set "$@" --db-${DB_TYPE}-election-timer={{ .OVN_ELECTION_TIMER }}
 set "$@" --db-${DB_TYPE}-cluster-local-addr=$(hostname).{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local
 set "$@" --db-${DB_TYPE}-cluster-local-port=${RAFT_PORT}
+{{ end -}}
 set "$@" --db-${DB_TYPE}-probe-interval-to-active={{ .OVN_PROBE_INTERVAL_TO_ACTIVE }}
 set "$@" --db-${DB_TYPE}-addr=${DB_ADDR}
 set "$@" --db-${DB_TYPE}-port=${DB_PORT}
@@ -63,16 +66,28 @@ set "$@" --db-${DB_TYPE}-port=${DB_PORT}
 set "$@" --ovn-${DB_TYPE}-db-ssl-key={{.OVNDB_KEY_PATH}}
 set "$@" --ovn-${DB_TYPE}-db-ssl-cert={{.OVNDB_CERT_PATH}}
 set "$@" --ovn-${DB_TYPE}-db-ssl-ca-cert={{.OVNDB_CACERT_PATH}}
-set "$@" --db-${DB_TYPE}-cluster-local-proto=ssl
-set "$@" --db-${DB_TYPE}-cluster-remote-proto=ssl
 set "$@" --db-${DB_TYPE}-create-insecure-remote=no
-{{- else }}
-set "$@" --db-${DB_TYPE}-cluster-local-proto=tcp
-set "$@" --db-${DB_TYPE}-cluster-remote-proto=tcp
+{{- end }}
+{{- if eq .MODE "clustered" }}
+set "$@" --db-${DB_TYPE}-cluster-local-proto={{ if .TLS }}ssl{{ else }}tcp{{ end }}
+set "$@" --db-${DB_TYPE}-cluster-remote-proto={{ if .TLS }}ssl{{ else }}tcp{{ end }}
 {{- end }}
 
-# log to console
-set "$@" --ovn-${DB_TYPE}-log=-vconsole:{{ .OVN_LOG_LEVEL }}
+# In active-backup mode every member but the active one replicates the
+# database from the active member.
+if [[ "${MODE}" == "active-backup" && "$(hostname)" != "${ACTIVE_MEMBER}" ]]; then
+    set "$@" --db-${DB_TYPE}-sync-from-addr=${ACTIVE_MEMBER}.{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local
+    set "$@" --db-${DB_TYPE}-sync-from-port=${DB_PORT}
+    set "$@" --db-${DB_TYPE}-sync-from-proto={{ if .TLS }}ssl{{ else }}tcp{{ end }}
+fi
+
+# log to console, the log levels are set by the operator and changed at
+# runtime through vlog/set
+LOG_OPTS="-vconsole:${OVN_LOG_LEVEL:-info}"
+for module in ${OVN_LOG_MODULES}; do
+    LOG_OPTS="${LOG_OPTS} -v${module%%:*}:console:${module##*:}"
+done
+set "$@" --ovn-${DB_TYPE}-log="${LOG_OPTS}"
 
 # if server attempts to log to file, ignore
 #
@@ -87,13 +102,16 @@ if ! [ -s $DB_FILE ]; then
     cleanup_db_file
 fi
 
+# Convert the database when the mode was changed.
+DB_LOCAL_ADDR={{ if .TLS }}ssl{{ else }}tcp{{ end }}:$(hostname).{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local:${RAFT_PORT}
+convert_db_file ${DB_LOCAL_ADDR}
+
 # Must remove a cluster member to change protocol, replicas 1/2 will have
 # left the cluster when terminating the pod, but cannot remove final member from
 # a cluster (replica 0).
 # Convert db to standalone mode on this member instead.
 # Cluster then gets recreated by ovnctl run_*b_ovsdb using the new local address.
-if [ "$(hostname)" == "{{ .SERVICE_NAME }}-0" ]; then
-    DB_LOCAL_ADDR={{ if .TLS }}ssl{{ else }}tcp{{ end }}:$(hostname).{{ .SERVICE_NAME }}.${NAMESPACE}.svc.cluster.local:${RAFT_PORT}
+if [[ "${MODE}" == "clustered" && "$(hostname)" == "{{ .SERVICE_NAME }}-0" ]]; then
     if [ -e ${DB_FILE} ] && \
        ovsdb-tool db-is-clustered ${DB_FILE} && \
        ACTUAL_DB_LOCAL_ADDR="$(ovsdb-tool db-local-address ${DB_FILE})" && \
@@ -111,15 +129,21 @@ fi
 trap wait_for_ovsdb_tool EXIT
 
 # don't log to file (we already log to console)
-$@ ${OPTS} run_${DB_TYPE}_ovsdb -- -vfile:off &
+# extra ovsdb-server options are validated against an allow-list by the operator
+$@ ${OPTS} run_${DB_TYPE}_ovsdb -- -vfile:off{{ range .OVSDB_SERVER_OPTIONS }} '{{ . }}'{{ end }} &
 
 # Once the database is running, we will attempt to configure db options
 CTLCMD="ovn-${DB_TYPE}ctl --no-leader-only"
 
 # Nothing special about the first pod, we just know that it always exists with
 # replicas > 0 and use it for configuration. In theory, this could be executed
-# in any other pod.
-if [[ "$(hostname)" == "{{ .SERVICE_NAME }}-0" ]]; then
+# in any other pod. In standalone and active-backup mode only the active member
+# accepts writes.
+CONFIG_MEMBER="{{ .SERVICE_NAME }}-0"
+if [[ "${MODE}" != "clustered" ]]; then
+    CONFIG_MEMBER=${ACTIVE_MEMBER}
+fi
+if [[ "$(hostname)" == "${CONFIG_MEMBER}" ]]; then
     # The command will wait until the daemon is connected and the DB is available
     # All following ctl invocation will use the local DB replica in the daemon
     export OVN_${DB_TYPE^^}_DAEMON=$(${CTLCMD} --pidfile --detach)
@@ -129,24 +153,10 @@ if [[ "$(hostname)" == "{{ .SERVICE_NAME }}-0" ]]; then
 {{- else }}
     ${CTLCMD} del-ssl
 {{- end }}
-    ${CTLCMD} set-connection ${DB_SCHEME}:${DB_PORT}:${DB_ADDR}
-
-    # OVN does not support setting inactivity-probe through --remote cli arg so
-    # we have to set it after database is up.
-    #
-    # In theory, ovsdb.local-config(5) could be used to configure inactivity
-    # probe using a local ovsdb-server. But the future of this database is
-    # unclear, and it was largely abandoned by the community in mid-flight, so
-    # no tools exist to configure connections using this database. It may even
-    # be that this scheme will be abandoned in the future, because its features
-    # are covered by ovs text config file support added in latest ovs releases.
-    #
-    # TODO: Consider migrating inactivity probe setting  to config files when
-    # we update to ovs 3.3. See --config-file in ovsdb-server(1) for more
-    # details.
-    while [ "$(${CTLCMD} get connection . inactivity_probe)" != "{{ .OVN_INACTIVITY_PROBE }}" ]; do
-        ${CTLCMD} --inactivity-probe={{ .OVN_INACTIVITY_PROBE }} set-connection ${DB_SCHEME}:${DB_PORT}:${DB_ADDR}
-    done
+    # The operator keeps the inactivity probe and the per remote options of
+    # the connection in sync with the spec. The probe is set right away so the
+    # clients are not disconnected by the default probe in the meantime.
+    ${CTLCMD} --inactivity-probe={{ .OVN_INACTIVITY_PROBE }} set-connection ${DB_SCHEME}:${DB_PORT}:${DB_ADDR}
     ${CTLCMD} list connection
 
     # The daemon is no longer needed, kill it
@@ -154,6 +164,15 @@ if [[ "$(hostname)" == "{{ .SERVICE_NAME }}-0" ]]; then
     unset OVN_${DB_TYPE^^}_DAEMON
 fi
 
+{{- if .UNIXCTL_COMMANDS }}
+
+# unixctl settings are local to every ovsdb-server
+wait_for_ovsdb_server
+{{- range .UNIXCTL_COMMANDS }}
+ovs-appctl -t /tmp/ovn${DB_TYPE}_db.ctl {{ . }}
+{{- end }}
+{{- end }}
+
 wait_for_ovsdb_tool
 trap - EXIT
 
@@ -0,0 +1,30 @@
+#!/bin/bash
+
+set -e
+
+APPCTL_TIMEOUT=${OVN_NORTHD_APPCTL_TIMEOUT:-4}
+
+error_exit() {
+    echo "$1" >&2
+    exit 1
+}
+
+# Check if ovn-northd is running
+check_ovn_northd_pid() {
+    if ! pidof -q ovn-northd; then
+        error_exit "ERROR - ovn-northd is not running"
+    fi
+}
+
+# Check if the unixctl socket of ovn-northd answers. The readiness probe
+# checks the status and the database connections, a restart would not fix
+# them
+check_ovn_northd_unixctl() {
+    if ! output=$(ovn-appctl -T "${APPCTL_TIMEOUT}" -t {{ .UnixctlSocket }} version 2>&1); then
+        error_exit "ERROR - ovn-northd unixctl socket did not answer within ${APPCTL_TIMEOUT}s: $output"
+    fi
+}
+
+
+check_ovn_northd_pid
+check_ovn_northd_unixctl
@@ -0,0 +1,14 @@
+#!/bin/bash
+
+# Samples the ovn-northd stopwatches into a file in the run directory shared
+# with ovn-northd, which is read by the operator.
+
+INTERVAL=${OVN_NORTHD_METRICS_INTERVAL:-30}
+OUTPUT={{ .StopwatchFile }}
+
+while true; do
+    if ovn-appctl -t {{ .UnixctlSocket }} stopwatch/show > "${OUTPUT}.new" 2>/dev/null; then
+        mv -f "${OUTPUT}.new" "${OUTPUT}"
+    fi
+    sleep "${INTERVAL}"
+done
@@ -0,0 +1,64 @@
+#!/bin/bash
+
+set -e
+
+APPCTL_TIMEOUT=${OVN_NORTHD_APPCTL_TIMEOUT:-4}
+# the ovn-appctl calls share the timeout, so the probe fails with their
+# error message before the kubelet kills it
+DEADLINE=$((SECONDS + APPCTL_TIMEOUT))
+
+error_exit() {
+    echo "$1" >&2
+    exit 1
+}
+
+# Print the time left for the next ovn-appctl call
+remaining_timeout() {
+    local remaining=$((DEADLINE - SECONDS))
+
+    if [ "$remaining" -lt 1 ]; then
+        error_exit "ERROR - ovn-northd did not answer within ${APPCTL_TIMEOUT}s"
+    fi
+    echo "$remaining"
+}
+
+# Check if ovn-northd is responsive and report whether it is the active instance.
+# ovn-northd answers unixctl requests between two iterations of its main loop,
+# so an answer within the timeout shows that the active instance keeps
+# processing its iterations
+check_ovn_northd_status() {
+    local timeout
+
+    timeout=$(remaining_timeout)
+    if ! output=$(ovn-appctl -T "${timeout}" -t {{ .UnixctlSocket }} status 2>&1); then
+        error_exit "ERROR - ovn-northd did not answer within ${APPCTL_TIMEOUT}s: $output"
+    fi
+
+    case "$output" in
+        "Status: active"|"Status: standby")
+            ;;
+        *)
+            error_exit "ERROR - ovn-northd status is '$output', expecting 'active' or 'standby' status"
+            ;;
+    esac
+}
+
+# Check if ovn-northd is connected to the OVN NB or SB database
+check_ovn_northd_connection() {
+    local db=$1
+    local timeout
+
+    timeout=$(remaining_timeout)
+    if ! output=$(ovn-appctl -T "${timeout}" -t {{ .UnixctlSocket }} "${db}-connection-status" 2>&1); then
+        error_exit "ERROR - Failed to get ${db} connection status from ovn-northd: $output"
+    fi
+
+    if [ "$output" != "connected" ]; then
+        error_exit "ERROR - ovn-northd ${db} connection status is '$output', expecting 'connected' status"
+    fi
+}
+
+
+check_ovn_northd_status
+check_ovn_northd_connection nb
+check_ovn_northd_connection sb
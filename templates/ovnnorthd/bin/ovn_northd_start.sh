@@ -0,0 +1,10 @@
+#!/bin/bash
+
+# The log levels are resolved on container start and changed at runtime by
+# the operator through vlog/set, so they are not part of the pod spec.
+LOG_OPTS=("-vconsole:${OVN_LOG_LEVEL:-info}")
+for module in ${OVN_LOG_MODULES}; do
+    LOG_OPTS+=("-v${module%%:*}:console:${module##*:}")
+done
+
+exec /usr/bin/ovn-northd "${LOG_OPTS[@]}" "$@"
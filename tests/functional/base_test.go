@@ -19,6 +19,7 @@ package functional_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
@@ -35,6 +36,8 @@ import (
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncontroller"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovndbcluster"
 )
 
 const (
@@ -229,10 +232,16 @@ func GetTLSOVNControllerSpec() ovnv1.OVNControllerSpec {
 	return spec
 }
 
+// CreateOVNController - creates the OVNController the OpenStackControlPlane
+// would create, which keeps the unscoped DaemonSet names and labels
 func CreateOVNController(namespace string, spec ovnv1.OVNControllerSpec) client.Object {
+	return CreateNamedOVNController(namespace, ovnv1.OVNControllerLegacyName, spec)
+}
 
-	name := ovn.CreateOVNController(namespace, spec)
-	return ovn.GetOVNController(name)
+func CreateNamedOVNController(namespace string, name string, spec ovnv1.OVNControllerSpec) client.Object {
+
+	instanceName := ovn.CreateNamedOVNController(namespace, name, spec)
+	return ovn.GetOVNController(instanceName)
 }
 
 func DeleteOVNController(instance types.NamespacedName) {
@@ -296,6 +305,90 @@ func SimulateDaemonsetNumberReadyWithPods(name types.NamespacedName, networkIPs
 	logger.Info("Simulated daemonset success", "on", name)
 }
 
+// SimulateIntegrityCheck - annotates the db pod with the output of check-db.sh,
+// as its integrity-check container does after each check, and triggers a
+// reconcile of the OVNDBCluster as the pods are not watched
+func SimulateIntegrityCheck(cluster types.NamespacedName, podName string, output string) {
+	Eventually(func(g Gomega) {
+		p := GetPod(types.NamespacedName{Namespace: cluster.Namespace, Name: podName})
+		if p.Annotations == nil {
+			p.Annotations = map[string]string{}
+		}
+		p.Annotations[ovndbcluster.IntegrityCheckAnnotation] = output
+		p.Annotations[ovndbcluster.IntegrityCheckTimeAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		g.Expect(k8sClient.Update(ctx, p)).To(Succeed())
+	}, timeout, interval).Should(Succeed())
+
+	Eventually(func(g Gomega) {
+		c := GetOVNDBCluster(cluster)
+		if c.Annotations == nil {
+			c.Annotations = map[string]string{}
+		}
+		c.Annotations["test"] = podName
+		g.Expect(k8sClient.Update(ctx, c)).To(Succeed())
+	}, timeout, interval).Should(Succeed())
+
+	logger.Info("Simulated integrity check", "on", podName)
+}
+
+// CreateOVNDBPod - creates a pod of the statefulset scheduled on a node with
+// the given Ready condition. Without a kubelet the pod stays terminating once
+// deleted.
+func CreateOVNDBPod(statefulSet types.NamespacedName, index int, ready corev1.ConditionStatus, since time.Time) *corev1.Pod {
+	ss := th.GetStatefulSet(statefulSet)
+	pod := &corev1.Pod{
+		ObjectMeta: *ss.Spec.Template.ObjectMeta.DeepCopy(),
+		Spec:       *ss.Spec.Template.Spec.DeepCopy(),
+	}
+	pod.Namespace = statefulSet.Namespace
+	pod.Name = fmt.Sprintf("%s-%d", statefulSet.Name, index)
+	pod.Spec.NodeName = "worker-0"
+	// the volume claims are not simulated
+	pod.Spec.Volumes = []corev1.Volume{}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].VolumeMounts = []corev1.VolumeMount{}
+	}
+	Expect(k8sClient.Create(ctx, pod)).Should(Succeed())
+
+	pod.Status.Conditions = []corev1.PodCondition{{
+		Type:               corev1.PodReady,
+		Status:             ready,
+		LastTransitionTime: metav1.NewTime(since),
+	}}
+	Expect(k8sClient.Status().Update(ctx, pod)).Should(Succeed())
+
+	return pod
+}
+
+// SimulateNodeConfigApplied - annotates the ovs pod with the hash of the node
+// configuration of the group, as its config agent does once it applied it
+func SimulateNodeConfigApplied(instance *ovnv1.OVNController, pod types.NamespacedName, group string) {
+	var hash string
+	Eventually(func(g Gomega) {
+		cm := th.GetConfigMap(types.NamespacedName{
+			Namespace: instance.Namespace,
+			Name:      ovncontroller.NodeConfigMapName(instance),
+		})
+		for _, line := range strings.Split(cm.Data[group], "\n") {
+			if value, ok := strings.CutPrefix(line, "ConfigHash="); ok {
+				hash = value
+			}
+		}
+		g.Expect(hash).ToNot(BeEmpty())
+	}, timeout, interval).Should(Succeed())
+
+	Eventually(func(g Gomega) {
+		p := GetPod(pod)
+		if p.Annotations == nil {
+			p.Annotations = map[string]string{}
+		}
+		p.Annotations[ovncontroller.ConfigHashAnnotation] = hash
+		g.Expect(k8sClient.Update(ctx, p)).To(Succeed())
+	}, timeout, interval).Should(Succeed())
+
+	logger.Info("Simulated node config applied", "on", pod)
+}
+
 func CreateNAD(name types.NamespacedName) *networkv1.NetworkAttachmentDefinition {
 	nad := &networkv1.NetworkAttachmentDefinition{
 		ObjectMeta: metav1.ObjectMeta{
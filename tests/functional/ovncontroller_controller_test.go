@@ -19,6 +19,7 @@ package functional_test
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
 	. "github.com/onsi/gomega"    //revive:disable:dot-imports
@@ -27,12 +28,23 @@ import (
 	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
 
 	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	ovn_common "github.com/openstack-k8s-operators/ovn-operator/pkg/common"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovncontroller"
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 var _ = Describe("OVNController controller", func() {
@@ -85,26 +97,30 @@ var _ = Describe("OVNController controller", func() {
 			)
 		})
 
-		It("should not create a config job", func() {
-			daemonSetName := types.NamespacedName{
-				Namespace: namespace,
-				Name:      "ovn-controller",
-			}
-			configJob := types.NamespacedName{
+		It("should not render the node configuration without the SB OVNDBCluster", func() {
+			th.AssertConfigMapDoesNotExist(types.NamespacedName{
 				Namespace: OVNControllerName.Namespace,
-				Name:      daemonSetName.Name + "-config",
-			}
-			th.AssertJobDoesNotExist(configJob)
+				Name:      OVNControllerName.Name + "-node-config",
+			})
+		})
 
-			daemonSetNameOVS := types.NamespacedName{
-				Namespace: namespace,
-				Name:      "ovn-controller-ovs",
+		It("should run the config agent in the ovs pods", func() {
+			ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+			names := []string{}
+			for _, container := range ds.Spec.Template.Spec.Containers {
+				names = append(names, container.Name)
 			}
-			configJobOVS := types.NamespacedName{
-				Namespace: OVNControllerName.Namespace,
-				Name:      daemonSetNameOVS.Name + "-config",
+			Expect(names).To(ContainElement("ovn-config"))
+
+			var volume *corev1.Volume
+			for i := range ds.Spec.Template.Spec.Volumes {
+				if ds.Spec.Template.Spec.Volumes[i].Name == "node-config" {
+					volume = &ds.Spec.Template.Spec.Volumes[i]
+				}
 			}
-			th.AssertJobDoesNotExist(configJobOVS)
+			Expect(volume).ToNot(BeNil())
+			Expect(volume.ConfigMap.Name).To(Equal(OVNControllerName.Name + "-node-config"))
+			Expect(volume.ConfigMap.Items).To(Equal([]corev1.KeyToPath{{Key: ovnv1.NodeGroupDefault, Path: "config"}}))
 		})
 
 		// TODO(ihar) introduce a new condition for the external config?
@@ -155,6 +171,24 @@ var _ = Describe("OVNController controller", func() {
 			Expect(ds.Spec.Template.Spec.Containers[1].ReadinessProbe).ShouldNot(BeNil())
 		})
 
+		It("waits for the SB OVNDBCluster", func() {
+			th.ExpectConditionWithDetails(
+				OVNControllerName,
+				ConditionGetterFunc(OVNControllerConditionGetter),
+				ovnv1.SBDBReadyCondition,
+				corev1.ConditionFalse,
+				condition.RequestedReason,
+				fmt.Sprintf(ovnv1.DBReadyMissingMessage, ovnv1.SBDBType),
+			)
+			th.ExpectCondition(
+				OVNControllerName,
+				ConditionGetterFunc(OVNControllerConditionGetter),
+				condition.ReadyCondition,
+				corev1.ConditionFalse,
+			)
+			Expect(GetOVNController(OVNControllerName).Status.SBEndpoint).To(BeEmpty())
+		})
+
 		When("OVNDBCluster instances are available without networkAttachments", func() {
 			var scriptsCM types.NamespacedName
 			var dbs []types.NamespacedName
@@ -177,23 +211,7 @@ var _ = Describe("OVNController controller", func() {
 				}
 			})
 
-			It("should create a config job for ovn-controller and not for ovn-controller-ovs", func() {
-				daemonSetName := types.NamespacedName{
-					Namespace: namespace,
-					Name:      "ovn-controller",
-				}
-				SimulateDaemonsetNumberReadyWithPods(
-					daemonSetName,
-					map[string][]string{},
-				)
-				configJob := types.NamespacedName{
-					Namespace: OVNControllerName.Namespace,
-					Name:      daemonSetName.Name + "-config",
-				}
-				Eventually(func() batchv1.Job {
-					return *th.GetJob(configJob)
-				}, timeout, interval).ShouldNot(BeNil())
-
+			It("should render the node configuration and wait for the config agents to apply it", func() {
 				daemonSetNameOVS := types.NamespacedName{
 					Namespace: namespace,
 					Name:      "ovn-controller-ovs",
@@ -202,11 +220,41 @@ var _ = Describe("OVNController controller", func() {
 					daemonSetNameOVS,
 					map[string][]string{},
 				)
-				configJobOVS := types.NamespacedName{
+				nodeConfigCM := types.NamespacedName{
 					Namespace: OVNControllerName.Namespace,
-					Name:      daemonSetNameOVS.Name + "-config",
+					Name:      OVNControllerName.Name + "-node-config",
 				}
-				th.AssertJobDoesNotExist(configJobOVS)
+				Eventually(func(g Gomega) {
+					config := th.GetConfigMap(nodeConfigCM).Data[ovnv1.NodeGroupDefault]
+					g.Expect(config).To(ContainSubstring("OVNRemote=tcp:ovsdbserver-sb.%s.svc:6642\n", namespace))
+					g.Expect(config).To(ContainSubstring("OVNEncapType=geneve\n"))
+					g.Expect(config).To(ContainSubstring("ConfigHash="))
+				}, timeout, interval).Should(Succeed())
+				th.ExpectCondition(
+					OVNControllerName,
+					ConditionGetterFunc(OVNControllerConditionGetter),
+					condition.ServiceConfigReadyCondition,
+					corev1.ConditionFalse,
+				)
+
+				SimulateNodeConfigApplied(GetOVNController(OVNControllerName), daemonSetNameOVS, ovnv1.NodeGroupDefault)
+				th.ExpectCondition(
+					OVNControllerName,
+					ConditionGetterFunc(OVNControllerConditionGetter),
+					condition.ServiceConfigReadyCondition,
+					corev1.ConditionTrue,
+				)
+				Expect(GetOVNController(OVNControllerName).Status.ConfigNumberApplied).To(Equal(int32(1)))
+				th.AssertJobDoesNotExist(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-config"})
+			})
+
+			It("reports the SB OVNDBCluster ready", func() {
+				th.ExpectCondition(
+					OVNControllerName,
+					ConditionGetterFunc(OVNControllerConditionGetter),
+					ovnv1.SBDBReadyCondition,
+					corev1.ConditionTrue,
+				)
 			})
 
 			It("should create a ConfigMap for start-vswitchd.sh with eth0 as Interface Name", func() {
@@ -255,22 +303,276 @@ var _ = Describe("OVNController controller", func() {
 				)
 			})
 
-			It("should create a config job", func() {
-				configJob := types.NamespacedName{
+			It("should wait for the config agents to apply a changed node configuration", func() {
+				SimulateNodeConfigApplied(GetOVNController(OVNControllerName), daemonSetNameOVS, ovnv1.NodeGroupDefault)
+				th.ExpectCondition(
+					OVNControllerName,
+					ConditionGetterFunc(OVNControllerConditionGetter),
+					condition.ServiceConfigReadyCondition,
+					corev1.ConditionTrue,
+				)
+
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.ExternalIDS.OvnEncapType = "vxlan"
+					g.Expect(k8sClient.Update(ctx, ovnController)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					config := th.GetConfigMap(types.NamespacedName{
+						Namespace: OVNControllerName.Namespace,
+						Name:      OVNControllerName.Name + "-node-config",
+					}).Data[ovnv1.NodeGroupDefault]
+					g.Expect(config).To(ContainSubstring("OVNEncapType=vxlan\n"))
+				}, timeout, interval).Should(Succeed())
+				th.ExpectCondition(
+					OVNControllerName,
+					ConditionGetterFunc(OVNControllerConditionGetter),
+					condition.ServiceConfigReadyCondition,
+					corev1.ConditionFalse,
+				)
+
+				// the ovs pods are not restarted
+				Expect(GetPod(daemonSetNameOVS).Annotations).To(HaveKey(ovncontroller.ConfigHashAnnotation))
+				SimulateNodeConfigApplied(GetOVNController(OVNControllerName), daemonSetNameOVS, ovnv1.NodeGroupDefault)
+				th.ExpectCondition(
+					OVNControllerName,
+					ConditionGetterFunc(OVNControllerConditionGetter),
+					condition.ServiceConfigReadyCondition,
+					corev1.ConditionTrue,
+				)
+			})
+
+			It("should render the extra external-ids and other_config", func() {
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.ExtraExternalIDs = map[string]string{
+						"ovn-monitor-all":             "true",
+						"ovn-openflow-probe-interval": "60",
+					}
+					ovnController.Spec.OVSOtherConfig = map[string]string{
+						"n-handler-threads": "4",
+					}
+					g.Expect(k8sClient.Update(ctx, ovnController)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				nodeConfigCM := types.NamespacedName{
 					Namespace: OVNControllerName.Namespace,
-					Name:      daemonSetName.Name + "-config",
+					Name:      OVNControllerName.Name + "-node-config",
 				}
-				Eventually(func() batchv1.Job {
-					return *th.GetJob(configJob)
-				}, timeout, interval).ShouldNot(BeNil())
+				Eventually(func(g Gomega) {
+					config := th.GetConfigMap(nodeConfigCM).Data[ovnv1.NodeGroupDefault]
+					g.Expect(config).To(ContainSubstring("ExtraExternalIDs=ovn-monitor-all=true ovn-openflow-probe-interval=60\n"))
+					g.Expect(config).To(ContainSubstring("OVSOtherConfig=n-handler-threads=4\n"))
+				}, timeout, interval).Should(Succeed())
+
+				// removing the keys removes them from the node configuration
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.ExtraExternalIDs = nil
+					ovnController.Spec.OVSOtherConfig = nil
+					g.Expect(k8sClient.Update(ctx, ovnController)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+				Eventually(func(g Gomega) {
+					config := th.GetConfigMap(nodeConfigCM).Data[ovnv1.NodeGroupDefault]
+					g.Expect(config).ToNot(ContainSubstring("ExtraExternalIDs="))
+					g.Expect(config).ToNot(ContainSubstring("OVSOtherConfig="))
+				}, timeout, interval).Should(Succeed())
+			})
+
+			It("should report the external-ids drift reported by the config agents", func() {
+				SimulateNodeConfigApplied(GetOVNController(OVNControllerName), daemonSetNameOVS, ovnv1.NodeGroupDefault)
+				th.ExpectCondition(
+					OVNControllerName,
+					ConditionGetterFunc(OVNControllerConditionGetter),
+					condition.ServiceConfigReadyCondition,
+					corev1.ConditionTrue,
+				)
+				Expect(GetOVNController(OVNControllerName).Status.Nodes).To(ContainElement(
+					HaveField("ExternalIDsDrift", BeEmpty())))
+
+				Eventually(func(g Gomega) {
+					pod := GetPod(daemonSetNameOVS)
+					pod.Annotations[ovncontroller.DriftAnnotation] = "ovn-encap-type,ovn-remote"
+					pod.Annotations[ovncontroller.RemediatedAnnotation] = "2024-05-01T10:00:00Z"
+					g.Expect(k8sClient.Update(ctx, pod)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+				// the pods are not watched, trigger a reconcile
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.ExternalIDsDriftRemediation = ovnv1.DriftRemediationManual
+					g.Expect(k8sClient.Update(ctx, ovnController)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					nodes := GetOVNController(OVNControllerName).Status.Nodes
+					g.Expect(nodes).To(HaveLen(2))
+					g.Expect(nodes[1].Node).To(Equal(daemonSetNameOVS.Name))
+					g.Expect(nodes[1].ExternalIDsDrift).To(Equal([]string{"ovn-encap-type", "ovn-remote"}))
+					g.Expect(nodes[1].LastRemediationTime).ToNot(BeNil())
+				}, timeout, interval).Should(Succeed())
+			})
+
+			It("should report the state of each node", func() {
+				SimulateNodeConfigApplied(GetOVNController(OVNControllerName), daemonSetNameOVS, ovnv1.NodeGroupDefault)
+				Eventually(func(g Gomega) {
+					pod := GetPod(daemonSetNameOVS)
+					pod.Annotations[ovncontroller.SystemIDAnnotation] = "5bf3a8b2-7c2e-4e0c-9f5e-0d1a2b3c4d5e"
+					pod.Annotations[ovncontroller.EncapIPAnnotation] = "172.19.0.100"
+					pod.Annotations[ovncontroller.BridgeMappingsAnnotation] = "physnet1:br-physnet1"
+					pod.Annotations[ovncontroller.ConfigAppliedAnnotation] = "2024-05-01T10:00:00Z"
+					g.Expect(k8sClient.Update(ctx, pod)).To(Succeed())
+					pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "ovs-vswitchd", Ready: true}}
+					g.Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+				Eventually(func(g Gomega) {
+					pod := GetPod(daemonSetName)
+					pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "ovn-controller", Ready: true}}
+					g.Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+				// the pods are not watched, trigger a reconcile
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Annotations = map[string]string{"test": "reconcile"}
+					g.Expect(k8sClient.Update(ctx, ovnController)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					nodes := GetOVNController(OVNControllerName).Status.Nodes
+					g.Expect(nodes).To(HaveLen(2))
+
+					g.Expect(nodes[0].Node).To(Equal(daemonSetName.Name))
+					g.Expect(nodes[0].OVNControllerConnected).To(BeTrue())
+
+					g.Expect(nodes[1].Node).To(Equal(daemonSetNameOVS.Name))
+					g.Expect(nodes[1].NodeGroup).To(Equal(ovnv1.NodeGroupDefault))
+					g.Expect(nodes[1].SystemID).To(Equal("5bf3a8b2-7c2e-4e0c-9f5e-0d1a2b3c4d5e"))
+					g.Expect(nodes[1].EncapIP).To(Equal("172.19.0.100"))
+					g.Expect(nodes[1].BridgeMappings).To(Equal([]string{"physnet1:br-physnet1"}))
+					g.Expect(nodes[1].ConfigHash).ToNot(BeEmpty())
+					g.Expect(nodes[1].ConfigUpToDate).To(BeTrue())
+					g.Expect(nodes[1].ConfigAppliedTime).ToNot(BeNil())
+					g.Expect(nodes[1].ConfigError).To(BeEmpty())
+					g.Expect(nodes[1].OVSReady).To(BeTrue())
+				}, timeout, interval).Should(Succeed())
 			})
 
-			It("should not create a config job", func() {
-				configJob := types.NamespacedName{
+			It("should report the error of the config agent", func() {
+				Eventually(func(g Gomega) {
+					pod := GetPod(daemonSetNameOVS)
+					pod.Annotations[ovncontroller.ConfigErrorAnnotation] = "ovs-vsctl: unix:/var/run/openvswitch/db.sock: database connection failed"
+					g.Expect(k8sClient.Update(ctx, pod)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Annotations = map[string]string{"test": "reconcile"}
+					g.Expect(k8sClient.Update(ctx, ovnController)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					nodes := GetOVNController(OVNControllerName).Status.Nodes
+					g.Expect(nodes).To(HaveLen(2))
+					g.Expect(nodes[1].ConfigUpToDate).To(BeFalse())
+					g.Expect(nodes[1].ConfigError).To(ContainSubstring("database connection failed"))
+				}, timeout, interval).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					events := &corev1.EventList{}
+					g.Expect(k8sClient.List(ctx, events, client.InNamespace(namespace))).To(Succeed())
+
+					messages := []string{}
+					for _, event := range events.Items {
+						if event.InvolvedObject.Name == OVNControllerName.Name && event.Reason == "NodeConfigFailed" {
+							g.Expect(event.Type).To(Equal(corev1.EventTypeWarning))
+							messages = append(messages, event.Message)
+						}
+					}
+					g.Expect(messages).To(ContainElement(ContainSubstring("database connection failed")))
+				}, timeout, interval).Should(Succeed())
+			})
+
+			It("should delete the config jobs of earlier releases", func() {
+				ovnController := GetOVNController(OVNControllerName)
+				jobName := types.NamespacedName{Namespace: namespace, Name: daemonSetNameOVS.Name + "-config"}
+				job := &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      jobName.Name,
+						Namespace: jobName.Namespace,
+						Labels: map[string]string{
+							common.AppSelector: ovnController.ScopedName(ovnv1.ServiceNameOVNController),
+						},
+					},
+					Spec: batchv1.JobSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								RestartPolicy: corev1.RestartPolicyNever,
+								Containers:    []corev1.Container{{Name: "ovn-config", Image: "test"}},
+							},
+						},
+					},
+				}
+				Expect(controllerutil.SetControllerReference(ovnController, job, k8sClient.Scheme())).To(Succeed())
+				Expect(k8sClient.Create(ctx, job)).To(Succeed())
+
+				// the jobs are not watched, trigger a reconcile
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Annotations = map[string]string{"test": "reconcile"}
+					g.Expect(k8sClient.Update(ctx, ovnController)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, jobName, &batchv1.Job{})
+					g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+				}, timeout, interval).Should(Succeed())
+			})
+
+			It("should re-apply the node configuration on demand", func() {
+				nodeConfigCM := types.NamespacedName{
 					Namespace: OVNControllerName.Namespace,
-					Name:      daemonSetNameOVS.Name + "-config",
+					Name:      OVNControllerName.Name + "-node-config",
 				}
-				th.AssertJobDoesNotExist(configJob)
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Spec.ExternalIDsDriftRemediation = ovnv1.DriftRemediationManual
+					g.Expect(k8sClient.Update(ctx, ovnController)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+				Eventually(func(g Gomega) {
+					config := th.GetConfigMap(nodeConfigCM).Data[ovnv1.NodeGroupDefault]
+					g.Expect(config).To(ContainSubstring("DriftRemediation=Manual\n"))
+					g.Expect(config).ToNot(ContainSubstring("RemediationRequest="))
+				}, timeout, interval).Should(Succeed())
+				SimulateNodeConfigApplied(GetOVNController(OVNControllerName), daemonSetNameOVS, ovnv1.NodeGroupDefault)
+				th.ExpectCondition(
+					OVNControllerName,
+					ConditionGetterFunc(OVNControllerConditionGetter),
+					condition.ServiceConfigReadyCondition,
+					corev1.ConditionTrue,
+				)
+
+				Eventually(func(g Gomega) {
+					ovnController := GetOVNController(OVNControllerName)
+					ovnController.Annotations = map[string]string{ovnv1.RemediateDriftAnnotation: "1"}
+					g.Expect(k8sClient.Update(ctx, ovnController)).To(Succeed())
+				}, timeout, interval).Should(Succeed())
+				Eventually(func(g Gomega) {
+					config := th.GetConfigMap(nodeConfigCM).Data[ovnv1.NodeGroupDefault]
+					g.Expect(config).To(ContainSubstring("RemediationRequest=1\n"))
+				}, timeout, interval).Should(Succeed())
+				th.ExpectCondition(
+					OVNControllerName,
+					ConditionGetterFunc(OVNControllerConditionGetter),
+					condition.ServiceConfigReadyCondition,
+					corev1.ConditionFalse,
+				)
+
+				SimulateNodeConfigApplied(GetOVNController(OVNControllerName), daemonSetNameOVS, ovnv1.NodeGroupDefault)
+				th.ExpectCondition(
+					OVNControllerName,
+					ConditionGetterFunc(OVNControllerConditionGetter),
+					condition.ServiceConfigReadyCondition,
+					corev1.ConditionTrue,
+				)
 			})
 
 		})
@@ -295,7 +597,7 @@ var _ = Describe("OVNController controller", func() {
 			DeferCleanup(th.DeleteInstance, instance)
 		})
 
-		It("should create a config job for ovn-controller and not for ovn-controller-ovs", func() {
+		It("should report the node configuration applied by the config agent of the ovs pod", func() {
 			daemonSetName := types.NamespacedName{
 				Namespace: namespace,
 				Name:      "ovn-controller",
@@ -313,18 +615,17 @@ var _ = Describe("OVNController controller", func() {
 				daemonSetNameOVS,
 				map[string][]string{namespace + "/internalapi": {"10.0.0.1"}},
 			)
-			configJob := types.NamespacedName{
+			SimulateNodeConfigApplied(GetOVNController(OVNControllerName), daemonSetNameOVS, ovnv1.NodeGroupDefault)
+			th.ExpectCondition(
+				OVNControllerName,
+				ConditionGetterFunc(OVNControllerConditionGetter),
+				condition.ServiceConfigReadyCondition,
+				corev1.ConditionTrue,
+			)
+			th.AssertJobDoesNotExist(types.NamespacedName{
 				Namespace: OVNControllerName.Namespace,
 				Name:      daemonSetName.Name + "-config",
-			}
-			configJobOVS := types.NamespacedName{
-				Namespace: OVNControllerName.Namespace,
-				Name:      daemonSetNameOVS.Name + "-config",
-			}
-			Eventually(func() batchv1.Job {
-				return *th.GetJob(configJob)
-			}, timeout, interval).ShouldNot(BeNil())
-			th.AssertJobDoesNotExist(configJobOVS)
+			})
 		})
 		It("reports that network attachment is missing", func() {
 
@@ -890,7 +1191,7 @@ var _ = Describe("OVNController controller", func() {
 			)
 		})
 
-		It("OVS Daemonset is created with 3 containers including an init container", func() {
+		It("OVS Daemonset is created with 4 containers including an init container", func() {
 			DeferCleanup(k8sClient.Delete, ctx, th.CreateCABundleSecret(types.NamespacedName{
 				Name:      CABundleSecretName,
 				Namespace: namespace,
@@ -921,7 +1222,7 @@ var _ = Describe("OVNController controller", func() {
 			ds = GetDaemonSet(daemonSetNameOVS)
 
 			Expect(ds.Spec.Template.Spec.InitContainers).To(HaveLen(1))
-			Expect(ds.Spec.Template.Spec.Containers).To(HaveLen(2))
+			Expect(ds.Spec.Template.Spec.Containers).To(HaveLen(3))
 		})
 
 		It("creates a Daemonset with TLS certs attached", func() {
@@ -1185,4 +1486,448 @@ var _ = Describe("OVNController controller", func() {
 			}, timeout, interval).Should(Succeed())
 		})
 	})
+
+	When("OVNController is created with tolerations and priorityClassName", func() {
+		It("sets them in the DaemonSets", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+
+			spec := GetDefaultOVNControllerSpec()
+			spec.Tolerations = []corev1.Toleration{
+				{
+					Key:      "node-role.kubernetes.io/networker",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			}
+			spec.PriorityClassName = "system-node-critical"
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			for _, name := range []string{"ovn-controller", "ovn-controller-ovs"} {
+				daemonSetName := types.NamespacedName{
+					Namespace: namespace,
+					Name:      name,
+				}
+				Eventually(func(g Gomega) {
+					podSpec := GetDaemonSet(daemonSetName).Spec.Template.Spec
+					g.Expect(podSpec.Tolerations).To(Equal(spec.Tolerations))
+					g.Expect(podSpec.PriorityClassName).To(Equal("system-node-critical"))
+				}, timeout, interval).Should(Succeed())
+			}
+		})
+	})
+
+	When("OVNController is created with DaemonSet overrides", func() {
+		It("applies each override to its DaemonSet", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+
+			spec := GetDefaultOVNControllerSpec()
+			spec.Override.DaemonSet = &runtime.RawExtension{
+				Raw: []byte(`{"spec": {"template": {"metadata": {"annotations": {"ovn": "true"}}}}}`),
+			}
+			spec.Override.OVSDaemonSet = &runtime.RawExtension{
+				Raw: []byte(`{"spec": {"template": {"metadata": {"annotations": {"ovs": "true"}}}}}`),
+			}
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			Eventually(func(g Gomega) {
+				ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller"})
+				g.Expect(ds.Spec.Template.Annotations).To(HaveKeyWithValue("ovn", "true"))
+				g.Expect(ds.Spec.Template.Annotations).NotTo(HaveKey("ovs"))
+				ovsDs := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+				g.Expect(ovsDs.Spec.Template.Annotations).To(HaveKeyWithValue("ovs", "true"))
+				g.Expect(ovsDs.Spec.Template.Annotations).NotTo(HaveKey("ovn"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rejects overrides which are not applied to the DaemonSets", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.Override.DaemonSet = &runtime.RawExtension{
+				Raw: []byte(`{"spec": {"updateStrategy": {"type": "OnDelete"}}}`),
+			}
+			spec.Override.OVSDaemonSet = &runtime.RawExtension{
+				Raw: []byte(`{"spec": {"template": {"metadata": {"labels": {"service": "foo", "team": "net"}}}}}`),
+			}
+			instance := &ovnv1.OVNController{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovncontroller-override",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.override.daemonSet.spec.updateStrategy"))
+			Expect(err.Error()).To(ContainSubstring("spec.override.ovsDaemonSet.spec.template.metadata.labels[service]"))
+			Expect(err.Error()).NotTo(ContainSubstring("labels[team]"))
+		})
+	})
+
+	When("OVNController is created with per container resources", func() {
+		It("uses them and falls back to the shared resources", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+
+			shared := corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+			}
+			vswitchd := corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			}
+			spec := GetDefaultOVNControllerSpec()
+			spec.Resources = shared
+			spec.ContainerResources.OVSVswitchd = &vswitchd
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			Eventually(func(g Gomega) {
+				ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+				resources := map[string]corev1.ResourceRequirements{}
+				for _, c := range ds.Spec.Template.Spec.Containers {
+					resources[c.Name] = c.Resources
+				}
+				g.Expect(resources["ovs-vswitchd"].Requests[corev1.ResourceMemory].Equal(resource.MustParse("1Gi"))).To(BeTrue())
+				g.Expect(resources["ovsdb-server"].Requests[corev1.ResourceMemory].Equal(resource.MustParse("128Mi"))).To(BeTrue())
+
+				ovnDs := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller"})
+				ovnRequests := ovnDs.Spec.Template.Spec.Containers[0].Resources.Requests
+				g.Expect(ovnRequests[corev1.ResourceMemory].Equal(resource.MustParse("128Mi"))).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNController is created with a sizing profile", func() {
+		It("expands the profile into the unset fields", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.Profile = ovnv1.ProfileLarge
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			controller := GetOVNController(types.NamespacedName{Namespace: namespace, Name: instance.GetName()})
+			Expect(controller.Spec.ExternalIDS.OvnRemoteProbeInterval).ToNot(BeNil())
+			Expect(*controller.Spec.ExternalIDS.OvnRemoteProbeInterval).To(Equal(int32(180000)))
+			Expect(controller.Spec.Resources.Requests.Memory().String()).To(Equal("1Gi"))
+		})
+
+		It("keeps an explicit remote probe interval", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.Profile = ovnv1.ProfileLarge
+			probeInterval := int32(0)
+			spec.ExternalIDS.OvnRemoteProbeInterval = &probeInterval
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			controller := GetOVNController(types.NamespacedName{Namespace: namespace, Name: instance.GetName()})
+			Expect(*controller.Spec.ExternalIDS.OvnRemoteProbeInterval).To(Equal(int32(0)))
+		})
+	})
+
+	When("OVNController is created with an invalid spec", func() {
+		It("rejects invalid nicMappings, external-ids and network attachments", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.NicMappings = map[string]string{
+				"physnet1":            "enp2s0",
+				"Physnet_2":           "enp3s0",
+				"datacentre-external": "enp4s0",
+				"tenant":              "enp5s0; reboot",
+			}
+			spec.ExternalIDS.OvnAvailabilityZones = []string{"az0", "az1:az2", "az0"}
+			spec.NetworkAttachment = "physnet1"
+			instance := &ovnv1.OVNController{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovncontroller-invalid",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.nicMappings[Physnet_2]"))
+			Expect(err.Error()).To(ContainSubstring("spec.nicMappings[datacentre-external]"))
+			Expect(err.Error()).To(ContainSubstring("spec.nicMappings[tenant]"))
+			Expect(err.Error()).To(ContainSubstring("spec.external-ids.availability-zones[1]"))
+			Expect(err.Error()).To(ContainSubstring("spec.external-ids.availability-zones[2]"))
+			Expect(err.Error()).To(ContainSubstring("spec.networkAttachment"))
+			Expect(err.Error()).ToNot(ContainSubstring("spec.nicMappings[physnet1]"))
+		})
+
+		It("rejects invalid or reserved extraExternalIDs and ovsOtherConfig keys", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.ExtraExternalIDs = map[string]string{
+				"ovn-monitor-all":             "true",
+				"ovn-chassis-mac-mappings":    "physnet1:0e:c4:aa:bb:cc:01",
+				"ovn-remote":                  "tcp:10.0.0.1:6642",
+				"ovn-openflow-probe-interval": "60 ; reboot",
+			}
+			spec.OVSOtherConfig = map[string]string{
+				"n-handler-threads": "4",
+				"max idle":          "10000",
+				"flow-restore-wait": "true",
+			}
+			errs := spec.OVNControllerSpecCore.ValidateCreate(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(4))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.extraExternalIDs[ovn-remote]: Forbidden"))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.extraExternalIDs[ovn-openflow-probe-interval]"))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.ovsOtherConfig[max idle]"))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.ovsOtherConfig[flow-restore-wait]: Forbidden"))
+		})
+
+		It("rejects an unsupported encapsulation type", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.ExternalIDS.OvnEncapType = "gre"
+			errs := spec.OVNControllerSpecCore.ValidateCreate(field.NewPath("spec"))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.external-ids.ovn-encap-type"))
+		})
+
+		It("warns about disruptive updates", func() {
+			old := GetDefaultOVNControllerSpec()
+			old.NicMappings = map[string]string{
+				"physnet1": "enp2s0",
+				"physnet2": "enp3s0",
+			}
+			old.ExternalIDS.OvnEncapType = "geneve"
+			spec := *old.DeepCopy()
+			delete(spec.NicMappings, "physnet1")
+			spec.NicMappings["physnet2"] = "enp4s0"
+			spec.ExternalIDS.OvnEncapType = "vxlan"
+
+			warnings, err := (&ovnv1.OVNController{Spec: spec}).ValidateUpdate(&ovnv1.OVNController{Spec: old})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(HaveLen(3))
+			Expect(warnings[0]).To(ContainSubstring("deletes bridge br-physnet1"))
+			Expect(warnings[1]).To(ContainSubstring("moving bridge br-physnet2 from enp3s0 to enp4s0"))
+			Expect(warnings[2]).To(ContainSubstring("spec.external-ids.ovn-encap-type"))
+		})
+	})
+
+	When("OVNController is created with node groups", func() {
+		var OVNControllerName types.NamespacedName
+		BeforeEach(func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.NodeSelector = &map[string]string{"openstack": "true"}
+			spec.NicMappings = map[string]string{
+				"physnet1": "enp2s0",
+			}
+			gwNicMappings := map[string]string{
+				"physnet1": "enp3s0",
+				"external": "enp4s0",
+			}
+			spec.NodeGroups = []ovnv1.OVNControllerNodeGroup{
+				{
+					Name:         "gateway",
+					NodeSelector: map[string]string{"node-role": "gateway"},
+					NicMappings:  &gwNicMappings,
+					ExternalIDS: &ovnv1.OVSExternalIDsOverride{
+						EnableChassisAsGateway: ptr.To(true),
+					},
+				},
+				{
+					Name:         "compute",
+					NodeSelector: map[string]string{"node-role": "compute"},
+					ExternalIDS: &ovnv1.OVSExternalIDsOverride{
+						EnableChassisAsGateway: ptr.To(false),
+					},
+				},
+			}
+			instance := CreateOVNController(namespace, spec)
+			OVNControllerName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+		})
+
+		It("creates an ovs DaemonSet per group which excludes the nodes of the groups before it", func() {
+			Eventually(func(g Gomega) {
+				ds := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+				g.Expect(ds.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"openstack": "true"}))
+				terms := ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+				g.Expect(terms).To(HaveLen(1))
+				g.Expect(terms[0].MatchExpressions).To(ConsistOf(
+					corev1.NodeSelectorRequirement{Key: "node-role", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"gateway"}},
+					corev1.NodeSelectorRequirement{Key: "node-role", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"compute"}},
+				))
+
+				gwDs := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs-gateway"})
+				g.Expect(gwDs.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{
+					"openstack": "true",
+					"node-role": "gateway",
+				}))
+				g.Expect(gwDs.Spec.Template.Spec.Affinity).To(BeNil())
+				g.Expect(gwDs.Spec.Template.Labels).To(HaveKeyWithValue("ovn.openstack.org/node-group", "gateway"))
+
+				computeDs := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs-compute"})
+				terms = computeDs.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+				g.Expect(terms).To(HaveLen(1))
+				g.Expect(terms[0].MatchExpressions).To(ConsistOf(
+					corev1.NodeSelectorRequirement{Key: "node-role", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"gateway"}},
+				))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("creates the NetworkAttachmentDefinitions of the groups with their own nicMappings", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(GetNAD(types.NamespacedName{Namespace: namespace, Name: "physnet1"}).Spec.Config).To(ContainSubstring("enp2s0"))
+				g.Expect(GetNAD(types.NamespacedName{Namespace: namespace, Name: "physnet1-gateway"}).Spec.Config).To(ContainSubstring("enp3s0"))
+				g.Expect(GetNAD(types.NamespacedName{Namespace: namespace, Name: "external-gateway"}).Spec.Config).To(ContainSubstring("enp4s0"))
+
+				// the NICs keep the name of the physnet in the pods
+				gwDs := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs-gateway"})
+				networks := []networkv1.NetworkSelectionElement{}
+				g.Expect(json.Unmarshal([]byte(gwDs.Spec.Template.Annotations[networkv1.NetworkAttachmentAnnot]), &networks)).To(Succeed())
+				interfaces := map[string]string{}
+				for _, network := range networks {
+					interfaces[network.Name] = network.InterfaceRequest
+				}
+				g.Expect(interfaces).To(Equal(map[string]string{
+					"physnet1-gateway": "physnet1",
+					"external-gateway": "external",
+				}))
+
+				// the compute group uses the NetworkAttachmentDefinitions of the spec
+				computeDs := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs-compute"})
+				g.Expect(computeDs.Spec.Template.Annotations[networkv1.NetworkAttachmentAnnot]).To(ContainSubstring(`"name":"physnet1"`))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("reports the status per group and removes the DaemonSet of a removed group", func() {
+			Eventually(func(g Gomega) {
+				groups := GetOVNController(OVNControllerName).Status.NodeGroups
+				names := []string{}
+				for _, group := range groups {
+					names = append(names, group.Name)
+				}
+				g.Expect(names).To(Equal([]string{ovnv1.NodeGroupDefault, "gateway", "compute"}))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				ovnController := GetOVNController(OVNControllerName)
+				ovnController.Spec.NodeGroups = ovnController.Spec.NodeGroups[:1]
+				g.Expect(k8sClient.Update(ctx, ovnController)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				dsList := ListDaemonsets(namespace)
+				names := []string{}
+				for _, ds := range dsList.Items {
+					names = append(names, ds.Name)
+				}
+				g.Expect(names).ToNot(ContainElement("ovn-controller-ovs-compute"))
+				g.Expect(names).To(ContainElement("ovn-controller-ovs-gateway"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("multiple OVNControllers are created in a namespace", func() {
+		var edgeName types.NamespacedName
+		BeforeEach(func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+
+			spec := GetDefaultOVNControllerSpec()
+			spec.NodeSelector = &map[string]string{"pool": "core"}
+			instance := CreateOVNController(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			edgeSpec := GetDefaultOVNControllerSpec()
+			edgeSpec.NodeSelector = &map[string]string{"pool": "edge"}
+			edgeSpec.NicMappings = map[string]string{"physnet1": "enp2s0"}
+			edge := CreateNamedOVNController(namespace, "edge", edgeSpec)
+			edgeName = types.NamespacedName{Name: edge.GetName(), Namespace: edge.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, edge)
+		})
+
+		It("scopes the DaemonSets, labels, host paths and NetworkAttachmentDefinitions to the CR", func() {
+			hostPaths := func(ds *appsv1.DaemonSet) []string {
+				paths := []string{}
+				for _, volume := range ds.Spec.Template.Spec.Volumes {
+					if volume.HostPath != nil {
+						paths = append(paths, volume.HostPath.Path)
+					}
+				}
+				return paths
+			}
+
+			Eventually(func(g Gomega) {
+				ovsDs := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "ovn-controller-ovs"})
+				g.Expect(ovsDs.Spec.Template.Labels).To(HaveKeyWithValue("service", "ovn-controller-ovs"))
+				g.Expect(hostPaths(ovsDs)).To(ContainElement(fmt.Sprintf("/var/home/core/%s/etc/ovs", namespace)))
+
+				edgeDs := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "edge-ovn-controller"})
+				g.Expect(edgeDs.Spec.Template.Labels).To(HaveKeyWithValue("service", "edge-ovn-controller"))
+				g.Expect(hostPaths(edgeDs)).To(ContainElement(fmt.Sprintf("/var/home/core/%s/edge/var/run/ovn", namespace)))
+
+				edgeOvsDs := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "edge-ovn-controller-ovs"})
+				g.Expect(edgeOvsDs.Spec.Template.Labels).To(HaveKeyWithValue("service", "edge-ovn-controller-ovs"))
+				g.Expect(edgeOvsDs.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"pool": "edge"}))
+				g.Expect(hostPaths(edgeOvsDs)).To(ContainElement(fmt.Sprintf("/var/home/core/%s/edge/etc/ovs", namespace)))
+				g.Expect(edgeOvsDs.Spec.Template.Annotations[networkv1.NetworkAttachmentAnnot]).To(ContainSubstring(`"name":"edge-physnet1"`))
+
+				g.Expect(GetNAD(types.NamespacedName{Namespace: namespace, Name: "edge-physnet1"}).Spec.Config).To(ContainSubstring("enp2s0"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("renders the node configuration of each OVNController", func() {
+			for _, name := range []string{"ovn-controller-ovs", "edge-ovn-controller-ovs"} {
+				SimulateDaemonsetNumberReady(types.NamespacedName{Namespace: namespace, Name: name})
+			}
+
+			Eventually(func(g Gomega) {
+				for _, name := range []string{"ovncontroller-node-config", "edge-node-config"} {
+					cm := th.GetConfigMap(types.NamespacedName{Namespace: namespace, Name: name})
+					g.Expect(cm.Data).To(HaveKey(ovnv1.NodeGroupDefault))
+				}
+				edgeOvsDs := GetDaemonSet(types.NamespacedName{Namespace: namespace, Name: "edge-ovn-controller-ovs"})
+				configMaps := []string{}
+				for _, volume := range edgeOvsDs.Spec.Template.Spec.Volumes {
+					if volume.ConfigMap != nil {
+						configMaps = append(configMaps, volume.ConfigMap.Name)
+					}
+				}
+				g.Expect(configMaps).To(ContainElement("edge-node-config"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rejects OVNControllers with overlapping node selectors", func() {
+			for _, nodeSelector := range []*map[string]string{
+				{"pool": "core", "zone": "a"},
+				{"zone": "a"},
+				nil,
+			} {
+				spec := GetDefaultOVNControllerSpec()
+				spec.NodeSelector = nodeSelector
+				Eventually(func(g Gomega) {
+					err := k8sClient.Create(ctx, &ovnv1.OVNController{
+						ObjectMeta: metav1.ObjectMeta{Name: "overlap", Namespace: namespace},
+						Spec:       spec,
+					})
+					g.Expect(err).To(HaveOccurred())
+					g.Expect(err.Error()).To(ContainSubstring("overlaps with the nodeSelector of OVNController %s", ovnv1.OVNControllerLegacyName))
+				}, timeout, interval).Should(Succeed())
+			}
+
+			Eventually(func(g Gomega) {
+				edge := GetOVNController(edgeName)
+				edge.Spec.NodeSelector = &map[string]string{"pool": "core"}
+				err := k8sClient.Update(ctx, edge)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("overlaps with the nodeSelector of OVNController %s", ovnv1.OVNControllerLegacyName))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rejects names which do not fit into the scoped names", func() {
+			spec := GetDefaultOVNControllerSpec()
+			spec.NodeSelector = &map[string]string{"pool": "other"}
+			err := k8sClient.Create(ctx, &ovnv1.OVNController{
+				ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 45), Namespace: namespace},
+				Spec:       spec,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("metadata.name"))
+		})
+	})
 })
@@ -19,6 +19,7 @@ package functional_test
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
 	. "github.com/onsi/gomega"    //revive:disable:dot-imports
@@ -30,10 +31,20 @@ import (
 	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/ovn-operator/pkg/ovndbcluster"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 var _ = Describe("OVNDBCluster controller", func() {
@@ -490,6 +501,41 @@ var _ = Describe("OVNDBCluster controller", func() {
 			}, timeout, interval).Should(Succeed())
 		})
 
+		It("should use the ovn-encap-type of the OpenStackControlPlane OVNController if there are several", func() {
+			ExpectedEncapType := "vxlan"
+			edgeSpec := GetDefaultOVNControllerSpec()
+			edgeSpec.NodeSelector = &map[string]string{"pool": "edge"}
+			edgeSpec.ExternalIDS.OvnEncapType = "geneve"
+			edge := CreateNamedOVNController(namespace, "edge", edgeSpec)
+			DeferCleanup(th.DeleteInstance, edge)
+			ovncontrollerSpec := GetDefaultOVNControllerSpec()
+			ovncontrollerSpec.NodeSelector = &map[string]string{"pool": "core"}
+			ovncontrollerSpec.ExternalIDS.OvnEncapType = ExpectedEncapType
+			ovnController := CreateOVNController(namespace, ovncontrollerSpec)
+			DeferCleanup(th.DeleteInstance, ovnController)
+			internalAPINADName := types.NamespacedName{Namespace: namespace, Name: "internalapi"}
+			nad := th.CreateNetworkAttachmentDefinition(internalAPINADName)
+			DeferCleanup(th.DeleteInstance, nad)
+
+			statefulSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-sb",
+			}
+			th.SimulateStatefulSetReplicaReadyWithPods(
+				statefulSetName,
+				map[string][]string{namespace + "/internalapi": {"10.0.0.1"}},
+			)
+
+			externalCM := types.NamespacedName{
+				Namespace: OVNDBClusterName.Namespace,
+				Name:      "ovncontroller-config",
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(th.GetConfigMap(externalCM).Data["ovsdb-config"]).Should(
+					ContainSubstring("ovn-encap-type: %s", ExpectedEncapType))
+			}, timeout, interval).Should(Succeed())
+		})
+
 		It("should remove ovnEncapType if OVNController gets deleted", func() {
 			ExpectedEncapType := "vxlan"
 			// Spawn OVNController with vxlan as ExternalIDs.OvnEncapType
@@ -1221,4 +1267,810 @@ var _ = Describe("OVNDBCluster controller", func() {
 		})
 
 	})
+
+	When("OVNDBCluster is created with integrity checks enabled", func() {
+		var OVNDBClusterName types.NamespacedName
+		var statefulSetName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.NetworkAttachment = "internalapi"
+			spec.Replicas = ptr.To[int32](2)
+			spec.IntegrityCheck.Enabled = true
+			instance := CreateOVNDBCluster(namespace, spec)
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+
+			nad := th.CreateNetworkAttachmentDefinition(types.NamespacedName{Namespace: namespace, Name: "internalapi"})
+			DeferCleanup(th.DeleteInstance, nad)
+
+			statefulSetName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+			th.SimulateStatefulSetReplicaReadyWithPods(
+				statefulSetName,
+				map[string][]string{namespace + "/internalapi": {"10.0.0.1"}},
+			)
+		})
+
+		It("should default the check interval", func() {
+			Expect(GetOVNDBCluster(OVNDBClusterName).Spec.IntegrityCheck.Interval).Should(Equal(int32(3600)))
+		})
+
+		It("should add the check scripts to the scripts ConfigMap", func() {
+			cm := types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%s", OVNDBClusterName.Name, "scripts"),
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(th.GetConfigMap(cm).Data["check-db.sh"]).Should(
+					ContainSubstring("ovsdb-tool check-cluster"))
+				g.Expect(th.GetConfigMap(cm).Data["integrity-check-agent.sh"]).Should(
+					ContainSubstring("check-db.sh"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should run the check in a container sharing the run directory", func() {
+			ss := th.GetStatefulSet(statefulSetName)
+			Expect(ss.Spec.Template.Spec.Containers).To(HaveLen(2))
+			check := ss.Spec.Template.Spec.Containers[1]
+			Expect(check.Name).To(Equal(ovndbcluster.IntegrityCheckContainerName))
+			Expect(check.Command).To(Equal([]string{ovndbcluster.IntegrityCheckAgentCommand}))
+			Expect(check.Env).To(ContainElement(corev1.EnvVar{Name: "IntegrityCheckInterval", Value: "3600"}))
+			for _, c := range ss.Spec.Template.Spec.Containers {
+				Expect(c.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "ovn-rundir", MountPath: "/tmp"}))
+			}
+		})
+
+		It("should report the result of the check of each member", func() {
+			SimulateIntegrityCheck(OVNDBClusterName, "ovsdbserver-nb-0",
+				"valid=true\napplied_index=1042\nchecksum=9f2c1a7e\n")
+			SimulateIntegrityCheck(OVNDBClusterName, "ovsdbserver-nb-1",
+				"valid=true\napplied_index=1042\nchecksum=9f2c1a7e\n")
+
+			Eventually(func(g Gomega) {
+				status := GetOVNDBCluster(OVNDBClusterName).Status.IntegrityCheck
+				g.Expect(status).ToNot(BeNil())
+				g.Expect(status.Members).To(HaveLen(2))
+				for _, m := range status.Members {
+					g.Expect(m.Valid).To(BeTrue())
+					g.Expect(m.AppliedIndex).To(Equal(int64(1042)))
+					g.Expect(m.Checksum).To(Equal("9f2c1a7e"))
+				}
+				g.Expect(status.Problems).To(BeEmpty())
+			}, timeout, interval).Should(Succeed())
+
+			th.ExpectCondition(
+				OVNDBClusterName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				ovnv1.DatabaseIntegrityCondition,
+				corev1.ConditionTrue,
+			)
+		})
+
+		It("should report members disagreeing on the contents without affecting Ready", func() {
+			SimulateIntegrityCheck(OVNDBClusterName, "ovsdbserver-nb-0",
+				"valid=true\napplied_index=1042\nchecksum=9f2c1a7e\n")
+			SimulateIntegrityCheck(OVNDBClusterName, "ovsdbserver-nb-1",
+				"valid=true\napplied_index=1042\nchecksum=0b3d5f7a\n")
+
+			th.ExpectConditionWithDetails(
+				OVNDBClusterName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				ovnv1.DatabaseIntegrityCondition,
+				corev1.ConditionFalse,
+				condition.ErrorReason,
+				"Database integrity check failed: members [ovsdbserver-nb-0] [ovsdbserver-nb-1] "+
+					"disagree on the database contents at index 1042",
+			)
+			th.ExpectCondition(
+				OVNDBClusterName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				condition.ReadyCondition,
+				corev1.ConditionTrue,
+			)
+		})
+
+		It("should report an invalid database file", func() {
+			SimulateIntegrityCheck(OVNDBClusterName, "ovsdbserver-nb-0",
+				"valid=false\nerror=ovsdb-tool: /etc/ovn/ovnnb_db.db: 1042: record 7 has inconsistent term 3 (expected 4)\n")
+
+			th.ExpectConditionWithDetails(
+				OVNDBClusterName,
+				ConditionGetterFunc(OVNDBClusterConditionGetter),
+				ovnv1.DatabaseIntegrityCondition,
+				corev1.ConditionFalse,
+				condition.ErrorReason,
+				"Database integrity check failed: ovsdbserver-nb-0: "+
+					"ovsdb-tool: /etc/ovn/ovnnb_db.db: 1042: record 7 has inconsistent term 3 (expected 4)",
+			)
+		})
+
+		It("should clear the result when the check gets disabled", func() {
+			SimulateIntegrityCheck(OVNDBClusterName, "ovsdbserver-nb-0",
+				"valid=true\napplied_index=1042\nchecksum=9f2c1a7e\n")
+			Eventually(func(g Gomega) {
+				g.Expect(GetOVNDBCluster(OVNDBClusterName).Status.IntegrityCheck).ToNot(BeNil())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				dbCluster := GetOVNDBCluster(OVNDBClusterName)
+				dbCluster.Spec.IntegrityCheck.Enabled = false
+				g.Expect(k8sClient.Update(ctx, dbCluster)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				dbCluster := GetOVNDBCluster(OVNDBClusterName)
+				g.Expect(dbCluster.Status.IntegrityCheck).To(BeNil())
+				g.Expect(dbCluster.Status.Conditions.Has(ovnv1.DatabaseIntegrityCondition)).To(BeFalse())
+				g.Expect(th.GetStatefulSet(statefulSetName).Spec.Template.Spec.Containers).To(HaveLen(1))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created in active-backup mode", func() {
+		var OVNDBClusterName types.NamespacedName
+		var statefulSetName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.Mode = ovnv1.DBModeActiveBackup
+			spec.Replicas = ptr.To[int32](2)
+			instance := CreateOVNDBCluster(namespace, spec)
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+
+			statefulSetName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+			th.SimulateStatefulSetReplicaReady(statefulSetName)
+		})
+
+		It("selects the first pod as active member", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(GetOVNDBCluster(OVNDBClusterName).Status.ActiveMember).To(Equal("ovsdbserver-nb-0"))
+			}, timeout, interval).Should(Succeed())
+
+			cm := th.GetConfigMap(types.NamespacedName{
+				Namespace: namespace,
+				Name:      OVNDBClusterName.Name + "-runtime",
+			})
+			Expect(cm.Data).To(HaveKeyWithValue("active-member", "ovsdbserver-nb-0"))
+		})
+
+		It("passes the active member to the pods", func() {
+			ss := th.GetStatefulSet(statefulSetName)
+			var activeMember *corev1.EnvVar
+			for i, e := range ss.Spec.Template.Spec.Containers[0].Env {
+				if e.Name == "ACTIVE_MEMBER" {
+					activeMember = &ss.Spec.Template.Spec.Containers[0].Env[i]
+				}
+			}
+			Expect(activeMember).ToNot(BeNil())
+			Expect(activeMember.ValueFrom.ConfigMapKeyRef.Name).To(Equal(OVNDBClusterName.Name + "-runtime"))
+		})
+
+		It("creates a service selecting the active member", func() {
+			Eventually(func(g Gomega) {
+				svc := th.GetService(types.NamespacedName{Namespace: namespace, Name: "ovsdbserver-nb-active"})
+				g.Expect(svc.Spec.Selector).To(HaveKeyWithValue("statefulset.kubernetes.io/pod-name", "ovsdbserver-nb-0"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("renders the scripts for active-backup", func() {
+			cm := types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%s", OVNDBClusterName.Name, "scripts"),
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(th.GetConfigMap(cm).Data["functions"]).Should(ContainSubstring("MODE=\"active-backup\""))
+				g.Expect(th.GetConfigMap(cm).Data["setup.sh"]).ShouldNot(ContainSubstring("--db-${DB_TYPE}-election-timer"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("defaults the failover timeout", func() {
+			Expect(GetOVNDBCluster(OVNDBClusterName).Spec.FailoverTimeout).To(Equal(int32(70)))
+		})
+
+		It("promotes the backup only once the former active member is fenced", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(GetOVNDBCluster(OVNDBClusterName).Status.ActiveMember).To(Equal("ovsdbserver-nb-0"))
+			}, timeout, interval).Should(Succeed())
+
+			active := CreateOVNDBPod(statefulSetName, 0, corev1.ConditionFalse, time.Now().Add(-time.Hour))
+			CreateOVNDBPod(statefulSetName, 1, corev1.ConditionTrue, time.Now())
+
+			// the pods are not watched, trigger a reconcile
+			Eventually(func(g Gomega) {
+				c := GetOVNDBCluster(OVNDBClusterName)
+				c.Annotations = map[string]string{"test": "failover"}
+				g.Expect(k8sClient.Update(ctx, c)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				status := GetOVNDBCluster(OVNDBClusterName).Status
+				g.Expect(status.ActiveMember).To(Equal("ovsdbserver-nb-1"))
+				g.Expect(status.Failover).ToNot(BeNil())
+				g.Expect(status.Failover.From).To(Equal("ovsdbserver-nb-0"))
+				g.Expect(status.Failover.FromUID).To(Equal(active.UID))
+				g.Expect(status.Failover.To).To(Equal("ovsdbserver-nb-1"))
+			}, timeout, interval).Should(Succeed())
+
+			// the former active member is deleted and restarts as backup
+			Expect(GetPod(types.NamespacedName{Namespace: namespace, Name: "ovsdbserver-nb-0"}).DeletionTimestamp).ToNot(BeNil())
+			cm := th.GetConfigMap(types.NamespacedName{
+				Namespace: namespace,
+				Name:      OVNDBClusterName.Name + "-runtime",
+			})
+			Expect(cm.Data).To(HaveKeyWithValue("active-member", "ovsdbserver-nb-1"))
+
+			// without a kubelet the pod stays terminating, it is not fenced and
+			// the service does not select the backup before it is promoted
+			Consistently(func(g Gomega) {
+				g.Expect(GetOVNDBCluster(OVNDBClusterName).Status.Failover).ToNot(BeNil())
+				svc := th.GetService(types.NamespacedName{Namespace: namespace, Name: "ovsdbserver-nb-active"})
+				g.Expect(svc.Spec.Selector).To(HaveKeyWithValue("statefulset.kubernetes.io/pod-name", "ovsdbserver-nb-0"))
+			}, "2s", interval).Should(Succeed())
+		})
+
+		It("keeps the active member while converting to clustered mode", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(GetOVNDBCluster(OVNDBClusterName).Status.ActiveMember).To(Equal("ovsdbserver-nb-0"))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				c := GetOVNDBCluster(OVNDBClusterName)
+				c.Spec.Mode = ovnv1.DBModeClustered
+				g.Expect(k8sClient.Update(ctx, c)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			cm := types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%s", OVNDBClusterName.Name, "scripts"),
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(th.GetConfigMap(cm).Data["functions"]).Should(ContainSubstring("MODE=\"clustered\""))
+			}, timeout, interval).Should(Succeed())
+
+			// the members are not restarted without a kubelet, the active
+			// member bootstraps the cluster once they are
+			Consistently(func(g Gomega) {
+				g.Expect(GetOVNDBCluster(OVNDBClusterName).Status.ActiveMember).To(Equal("ovsdbserver-nb-0"))
+				runtimeCM := th.GetConfigMap(types.NamespacedName{
+					Namespace: namespace,
+					Name:      OVNDBClusterName.Name + "-runtime",
+				})
+				g.Expect(runtimeCM.Data).To(HaveKeyWithValue("active-member", "ovsdbserver-nb-0"))
+			}, "2s", interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created in standalone mode", func() {
+		It("rejects more than one replica", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.Mode = ovnv1.DBModeStandalone
+			spec.Replicas = ptr.To[int32](3)
+			instance := &ovnv1.OVNDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovndbcluster-standalone",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must be 0 or 1 in standalone mode"))
+		})
+	})
+
+	When("OVNDBCluster is created with extraArgs", func() {
+		It("renders the server settings into the scripts", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.ExtraArgs = ovnv1.OVNDBClusterExtraArgs{
+				ServerOptions: []string{"--disable-file-column-diff"},
+				Unixctl: []ovnv1.UnixctlCommand{
+					{
+						Command: "ovsdb-server/memory-trim-on-compaction",
+						Args:    []string{"on"},
+					},
+				},
+				Remote: ovnv1.OVNDBRemoteOptions{
+					Dscp:       ptr.To[int32](46),
+					MaxBackoff: ptr.To[int32](8000),
+				},
+			}
+			instance := CreateOVNDBCluster(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			cm := types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%s", instance.GetName(), "scripts"),
+			}
+			Eventually(func(g Gomega) {
+				setup := th.GetConfigMap(cm).Data["setup.sh"]
+				g.Expect(setup).To(ContainSubstring("-vfile:off '--disable-file-column-diff'"))
+				g.Expect(setup).To(ContainSubstring("ovsdb-server/memory-trim-on-compaction on"))
+				// the remote options are applied by the operator
+				g.Expect(setup).NotTo(ContainSubstring("max_backoff"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rejects options which are not allowed", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.ExtraArgs.ServerOptions = []string{"--remote=ptcp:6640"}
+			spec.ExtraArgs.Unixctl = []ovnv1.UnixctlCommand{{Command: "exit"}}
+			instance := &ovnv1.OVNDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovndbcluster-extraargs",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.extraArgs.serverOptions[0]"))
+			Expect(err.Error()).To(ContainSubstring("spec.extraArgs.unixctl[0].command"))
+		})
+
+		It("validates the shell metacharacters without the webhook", func() {
+			extraArgs := ovnv1.OVNDBClusterExtraArgs{
+				ServerOptions: []string{"--disable-file-column-diff='; reboot'"},
+				Unixctl: []ovnv1.UnixctlCommand{
+					{
+						Command: "ovsdb-server/memory-trim-on-compaction",
+						Args:    []string{"on; reboot"},
+					},
+				},
+			}
+			errs := extraArgs.Validate(field.NewPath("spec", "extraArgs"))
+			Expect(errs).To(HaveLen(2))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.extraArgs.serverOptions[0]"))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.extraArgs.unixctl[0].args[0]"))
+		})
+	})
+
+	When("OVNDBCluster is created with log modules", func() {
+		var OVNDBClusterName types.NamespacedName
+		var statefulSetName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.LogModules = map[string]string{"raft": "dbg", "jsonrpc": "info"}
+			instance := CreateOVNDBCluster(namespace, spec)
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+
+			statefulSetName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+		})
+
+		It("passes the log levels to the pods through the runtime ConfigMap", func() {
+			runtimeCM := types.NamespacedName{
+				Namespace: namespace,
+				Name:      OVNDBClusterName.Name + "-runtime",
+			}
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(runtimeCM)
+				g.Expect(cm.Data).To(HaveKeyWithValue("log-level", "info"))
+				g.Expect(cm.Data).To(HaveKeyWithValue("log-modules", "jsonrpc:info raft:dbg"))
+			}, timeout, interval).Should(Succeed())
+
+			ss := th.GetStatefulSet(statefulSetName)
+			envNames := []string{}
+			for _, e := range ss.Spec.Template.Spec.Containers[0].Env {
+				if e.ValueFrom != nil && e.ValueFrom.ConfigMapKeyRef != nil {
+					Expect(e.ValueFrom.ConfigMapKeyRef.Name).To(Equal(runtimeCM.Name))
+					envNames = append(envNames, e.Name)
+				}
+			}
+			Expect(envNames).To(ContainElements("OVN_LOG_LEVEL", "OVN_LOG_MODULES"))
+		})
+
+		It("does not restart the pods when the log level changes", func() {
+			var configHash string
+			Eventually(func(g Gomega) {
+				ss := th.GetStatefulSet(statefulSetName)
+				for _, e := range ss.Spec.Template.Spec.Containers[0].Env {
+					if e.Name == "CONFIG_HASH" {
+						configHash = e.Value
+					}
+				}
+				g.Expect(configHash).NotTo(BeEmpty())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				cluster := GetOVNDBCluster(OVNDBClusterName)
+				cluster.Spec.LogLevel = "dbg"
+				g.Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(types.NamespacedName{
+					Namespace: namespace,
+					Name:      OVNDBClusterName.Name + "-runtime",
+				})
+				g.Expect(cm.Data).To(HaveKeyWithValue("log-level", "dbg"))
+				g.Expect(cm.Data).To(HaveKeyWithValue("log-modules", "jsonrpc:info raft:dbg"))
+			}, timeout, interval).Should(Succeed())
+
+			ss := th.GetStatefulSet(statefulSetName)
+			for _, e := range ss.Spec.Template.Spec.Containers[0].Env {
+				if e.Name == "CONFIG_HASH" {
+					Expect(e.Value).To(Equal(configHash))
+				}
+			}
+		})
+
+		It("rejects unknown log levels", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.LogModules = map[string]string{"raft": "debug"}
+			instance := &ovnv1.OVNDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovndbcluster-logmodules",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.logModules[raft]"))
+		})
+	})
+
+	When("OVNDBCluster is created with multiple replicas", func() {
+		var OVNDBClusterName types.NamespacedName
+		var pdbName types.NamespacedName
+
+		BeforeEach(func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.Replicas = ptr.To[int32](3)
+			instance := CreateOVNDBCluster(namespace, spec)
+			OVNDBClusterName = types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+			DeferCleanup(th.DeleteInstance, instance)
+
+			pdbName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+		})
+
+		It("creates a PodDisruptionBudget preserving the quorum", func() {
+			Eventually(func(g Gomega) {
+				pdb := &policyv1.PodDisruptionBudget{}
+				g.Expect(k8sClient.Get(ctx, pdbName, pdb)).To(Succeed())
+				g.Expect(pdb.Spec.MinAvailable.IntValue()).To(Equal(2))
+				g.Expect(pdb.Spec.Selector.MatchLabels).To(HaveKeyWithValue("service", "ovsdbserver-nb"))
+				g.Expect(pdb.OwnerReferences).To(HaveLen(1))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("updates the PodDisruptionBudget when replicas change", func() {
+			Eventually(func(g Gomega) {
+				cluster := GetOVNDBCluster(OVNDBClusterName)
+				cluster.Spec.Replicas = ptr.To[int32](5)
+				g.Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				pdb := &policyv1.PodDisruptionBudget{}
+				g.Expect(k8sClient.Get(ctx, pdbName, pdb)).To(Succeed())
+				g.Expect(pdb.Spec.MinAvailable.IntValue()).To(Equal(3))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("deletes the PodDisruptionBudget when it is disabled", func() {
+			Eventually(func(g Gomega) {
+				pdb := &policyv1.PodDisruptionBudget{}
+				g.Expect(k8sClient.Get(ctx, pdbName, pdb)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				cluster := GetOVNDBCluster(OVNDBClusterName)
+				cluster.Spec.Override.PodDisruptionBudget = &ovnv1.PodDisruptionBudgetOverrideSpec{
+					Enabled: ptr.To(false),
+				}
+				g.Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				pdb := &policyv1.PodDisruptionBudget{}
+				err := k8sClient.Get(ctx, pdbName, pdb)
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created with two replicas", func() {
+		It("creates a PodDisruptionBudget which doesn't block node drains", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.Replicas = ptr.To[int32](2)
+			instance := CreateOVNDBCluster(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			Eventually(func(g Gomega) {
+				pdb := &policyv1.PodDisruptionBudget{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "ovsdbserver-nb"}, pdb)).To(Succeed())
+				g.Expect(pdb.Spec.MinAvailable).To(BeNil())
+				g.Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a PodDisruptionBudget not owned by the OVNDBCluster exists", func() {
+		It("is not deleted", func() {
+			pdbName := types.NamespacedName{Namespace: namespace, Name: "ovsdbserver-nb"}
+			foreign := &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      pdbName.Name,
+					Namespace: pdbName.Namespace,
+				},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					MinAvailable: ptr.To(intstr.FromInt32(1)),
+					Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, foreign)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, foreign)
+
+			// a single replica doesn't get a PodDisruptionBudget
+			instance := CreateOVNDBCluster(namespace, GetDefaultOVNDBClusterSpec())
+			DeferCleanup(th.DeleteInstance, instance)
+			th.SimulateStatefulSetReplicaReady(types.NamespacedName{Namespace: namespace, Name: "ovsdbserver-nb"})
+
+			Consistently(func(g Gomega) {
+				pdb := &policyv1.PodDisruptionBudget{}
+				g.Expect(k8sClient.Get(ctx, pdbName, pdb)).To(Succeed())
+				g.Expect(pdb.UID).To(Equal(foreign.UID))
+			}, "2s", interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created with scheduling settings", func() {
+		It("sets them in the StatefulSet", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.Tolerations = []corev1.Toleration{
+				{
+					Key:      "node-role.kubernetes.io/control-plane",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			}
+			spec.PriorityClassName = "system-cluster-critical"
+			spec.AntiAffinity = ovnv1.AntiAffinityRequired
+			spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       corev1.LabelTopologyZone,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+				},
+			}
+			instance := CreateOVNDBCluster(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			statefulSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+			Eventually(func(g Gomega) {
+				podSpec := th.GetStatefulSet(statefulSetName).Spec.Template.Spec
+				g.Expect(podSpec.Tolerations).To(Equal(spec.Tolerations))
+				g.Expect(podSpec.PriorityClassName).To(Equal("system-cluster-critical"))
+
+				antiAffinity := podSpec.Affinity.PodAntiAffinity
+				g.Expect(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+				g.Expect(antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+				g.Expect(antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].TopologyKey).To(Equal(corev1.LabelHostname))
+
+				g.Expect(podSpec.TopologySpreadConstraints).To(HaveLen(1))
+				g.Expect(podSpec.TopologySpreadConstraints[0].TopologyKey).To(Equal(corev1.LabelTopologyZone))
+				g.Expect(podSpec.TopologySpreadConstraints[0].LabelSelector.MatchLabels).To(
+					HaveKeyWithValue("service", "ovsdbserver-nb"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created with a StatefulSet override", func() {
+		It("applies the override on top of the generated StatefulSet", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.Override.StatefulSet = &runtime.RawExtension{
+				Raw: []byte(`{
+					"spec": {
+						"updateStrategy": {"type": "OnDelete"},
+						"template": {
+							"metadata": {"annotations": {"backup.example.com/hook": "true"}},
+							"spec": {
+								"containers": [{
+									"name": "ovsdbserver-nb",
+									"env": [{"name": "EXTRA", "value": "foo"}]
+								}]
+							}
+						}
+					}
+				}`),
+			}
+			instance := CreateOVNDBCluster(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			statefulSetName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovsdbserver-nb",
+			}
+			Eventually(func(g Gomega) {
+				ss := th.GetStatefulSet(statefulSetName)
+				g.Expect(ss.Spec.UpdateStrategy.Type).To(Equal(appsv1.OnDeleteStatefulSetStrategyType))
+				template := ss.Spec.Template
+				g.Expect(template.Annotations).To(HaveKeyWithValue("backup.example.com/hook", "true"))
+				g.Expect(template.Spec.Containers).To(HaveLen(1))
+				envNames := []string{}
+				for _, e := range template.Spec.Containers[0].Env {
+					envNames = append(envNames, e.Name)
+				}
+				// the generated env is kept
+				g.Expect(envNames).To(ContainElements("EXTRA", "CONFIG_HASH", "OVN_RUNDIR"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rejects overrides of fields managed by the operator", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.Override.StatefulSet = &runtime.RawExtension{
+				Raw: []byte(`{
+					"metadata": {"name": "foo"},
+					"spec": {
+						"selector": {"matchLabels": {"foo": "bar"}},
+						"template": {"metadata": {"labels": {"service": "foo"}}},
+						"unknownField": true
+					}
+				}`),
+			}
+			instance := &ovnv1.OVNDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovndbcluster-override",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.override.statefulSet.metadata.name"))
+			Expect(err.Error()).To(ContainSubstring("spec.override.statefulSet.spec.selector"))
+			Expect(err.Error()).To(ContainSubstring("spec.override.statefulSet.spec.template.metadata.labels[service]"))
+			Expect(err.Error()).To(ContainSubstring("unknownField"))
+		})
+	})
+
+	When("OVNDBCluster is created", func() {
+		It("reports its state in the operator metrics", func() {
+			instance := CreateOVNDBCluster(namespace, GetDefaultOVNDBClusterSpec())
+			DeferCleanup(th.DeleteInstance, instance)
+
+			Eventually(func(g Gomega) {
+				families, err := ctrlmetrics.Registry.Gather()
+				g.Expect(err).ToNot(HaveOccurred())
+
+				found := map[string]bool{}
+				for _, family := range families {
+					for _, metric := range family.GetMetric() {
+						labels := map[string]string{}
+						for _, label := range metric.GetLabel() {
+							labels[label.GetName()] = label.GetValue()
+						}
+						if labels["namespace"] == namespace && labels["name"] == instance.GetName() {
+							found[family.GetName()] = true
+						}
+					}
+				}
+				g.Expect(found).To(HaveKey("ovn_operator_resource_ready"))
+				g.Expect(found).To(HaveKey("ovn_operator_condition_age_seconds"))
+				g.Expect(found).To(HaveKey("ovn_operator_dbcluster_desired_members"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster conditions change", func() {
+		It("emits events for the condition transitions", func() {
+			instance := CreateOVNDBCluster(namespace, GetDefaultOVNDBClusterSpec())
+			DeferCleanup(th.DeleteInstance, instance)
+
+			Eventually(func(g Gomega) {
+				events := &corev1.EventList{}
+				g.Expect(k8sClient.List(ctx, events, client.InNamespace(namespace))).To(Succeed())
+
+				reasons := []string{}
+				for _, event := range events.Items {
+					if event.InvolvedObject.Name == instance.GetName() {
+						reasons = append(reasons, event.Reason)
+					}
+				}
+				g.Expect(reasons).To(ContainElement("InputReadyTrue"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNDBCluster is created with database options", func() {
+		It("accepts valid options", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.Options = map[string]string{
+				"northd_probe_interval": "5000",
+				"mac_prefix":            "0a:00:00",
+			}
+			instance := CreateOVNDBCluster(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			cluster := GetOVNDBCluster(types.NamespacedName{Namespace: namespace, Name: instance.GetName()})
+			Expect(cluster.Spec.Options).To(HaveKeyWithValue("mac_prefix", "0a:00:00"))
+		})
+
+		It("rejects reserved options and unsupported values", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.Options = map[string]string{
+				"northd_internal_version": "24.03",
+				"mac_prefix":              "0a:00:00\"; rm -rf /",
+			}
+			instance := &ovnv1.OVNDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovndbcluster-options",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.options[northd_internal_version]"))
+			Expect(err.Error()).To(ContainSubstring("spec.options[mac_prefix]"))
+		})
+	})
+
+	When("OVNDBCluster is created with a sizing profile", func() {
+		It("expands the profile into the unset fields", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.Profile = ovnv1.ProfileLarge
+			spec.ElectionTimer = 20000
+			instance := CreateOVNDBCluster(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			cluster := GetOVNDBCluster(types.NamespacedName{Namespace: namespace, Name: instance.GetName()})
+			Expect(*cluster.Spec.Replicas).To(Equal(int32(3)))
+			// explicit fields take precedence over the profile
+			Expect(cluster.Spec.ElectionTimer).To(Equal(int32(20000)))
+			Expect(*cluster.Spec.InactivityProbe).To(Equal(int32(180000)))
+			Expect(*cluster.Spec.ProbeIntervalToActive).To(Equal(int32(180000)))
+			Expect(cluster.Spec.Resources.Requests.Memory().String()).To(Equal("4Gi"))
+		})
+
+		It("keeps explicitly disabled probes", func() {
+			spec := GetDefaultOVNDBClusterSpec()
+			spec.Profile = ovnv1.ProfileLarge
+			spec.InactivityProbe = ptr.To[int32](0)
+			instance := CreateOVNDBCluster(namespace, spec)
+			DeferCleanup(th.DeleteInstance, instance)
+
+			cluster := GetOVNDBCluster(types.NamespacedName{Namespace: namespace, Name: instance.GetName()})
+			Expect(*cluster.Spec.InactivityProbe).To(Equal(int32(0)))
+			Expect(*cluster.Spec.ProbeIntervalToActive).To(Equal(int32(180000)))
+
+			Eventually(func(g Gomega) {
+				setup := th.GetConfigMap(types.NamespacedName{
+					Namespace: namespace,
+					Name:      fmt.Sprintf("%s-%s", instance.GetName(), "scripts"),
+				}).Data["setup.sh"]
+				g.Expect(setup).To(ContainSubstring("--inactivity-probe=0 set-connection"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("uses the regular defaults without a profile", func() {
+			instance := CreateOVNDBCluster(namespace, GetDefaultOVNDBClusterSpec())
+			DeferCleanup(th.DeleteInstance, instance)
+
+			cluster := GetOVNDBCluster(types.NamespacedName{Namespace: namespace, Name: instance.GetName()})
+			Expect(*cluster.Spec.Replicas).To(Equal(int32(1)))
+			Expect(cluster.Spec.ElectionTimer).To(Equal(int32(10000)))
+			Expect(*cluster.Spec.InactivityProbe).To(Equal(int32(60000)))
+			Expect(*cluster.Spec.ProbeIntervalToActive).To(Equal(int32(60000)))
+			Expect(cluster.Spec.Resources.Requests).To(BeEmpty())
+		})
+	})
 })
@@ -26,8 +26,16 @@ import (
 	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
 
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	ovnv1 "github.com/openstack-k8s-operators/ovn-operator/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 )
 
 var _ = Describe("OVNNorthd controller", func() {
@@ -66,7 +74,56 @@ var _ = Describe("OVNNorthd controller", func() {
 			)
 		})
 
+		It("waits for the OVNDBClusters", func() {
+			th.ExpectConditionWithDetails(
+				ovnNorthdName,
+				ConditionGetterFunc(OVNNorthdConditionGetter),
+				ovnv1.NBDBReadyCondition,
+				corev1.ConditionFalse,
+				condition.RequestedReason,
+				fmt.Sprintf(ovnv1.DBReadyMissingMessage, ovnv1.NBDBType),
+			)
+			th.ExpectConditionWithDetails(
+				ovnNorthdName,
+				ConditionGetterFunc(OVNNorthdConditionGetter),
+				ovnv1.SBDBReadyCondition,
+				corev1.ConditionFalse,
+				condition.RequestedReason,
+				fmt.Sprintf(ovnv1.DBReadyMissingMessage, ovnv1.SBDBType),
+			)
+			th.ExpectCondition(
+				ovnNorthdName,
+				ConditionGetterFunc(OVNNorthdConditionGetter),
+				condition.ReadyCondition,
+				corev1.ConditionFalse,
+			)
+			Expect(GetOVNNorthd(ovnNorthdName).Status.NBEndpoint).To(BeEmpty())
+		})
+
 		When("OVNDBCluster instances are available", func() {
+			It("reports the OVNDBClusters ready and the endpoints in use", func() {
+				dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+				DeferCleanup(DeleteOVNDBClusters, dbs)
+
+				th.ExpectCondition(
+					ovnNorthdName,
+					ConditionGetterFunc(OVNNorthdConditionGetter),
+					ovnv1.NBDBReadyCondition,
+					corev1.ConditionTrue,
+				)
+				th.ExpectCondition(
+					ovnNorthdName,
+					ConditionGetterFunc(OVNNorthdConditionGetter),
+					ovnv1.SBDBReadyCondition,
+					corev1.ConditionTrue,
+				)
+				Eventually(func(g Gomega) {
+					status := GetOVNNorthd(ovnNorthdName).Status
+					g.Expect(status.NBEndpoint).To(Equal("tcp:ovsdbserver-nb-0." + namespace + ".svc.cluster.local:6641"))
+					g.Expect(status.SBEndpoint).To(Equal("tcp:ovsdbserver-sb-0." + namespace + ".svc.cluster.local:6642"))
+				}, timeout, interval).Should(Succeed())
+			})
+
 			It("should create a Deployment with the ovn connection CLI args set based on the OVNDBCluster", func() {
 				OVNNorthd := ovn.GetOVNNorthd(ovnNorthdName)
 				dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
@@ -78,9 +135,12 @@ var _ = Describe("OVNNorthd controller", func() {
 				}
 
 				depl := th.GetDeployment(deplName)
+				Expect(depl.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{
+					"/usr/local/bin/container-scripts/ovn_northd_start.sh",
+				}))
 				Expect(depl.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{
 					"-vfile:off",
-					"-vconsole:info",
+					"--unixctl=/tmp/ovn-northd.ctl",
 					fmt.Sprintf("--n-threads=%d", *OVNNorthd.Spec.NThreads),
 					"--ovnnb-db=tcp:ovsdbserver-nb-0." + namespace + ".svc.cluster.local:6641",
 					"--ovnsb-db=tcp:ovsdbserver-sb-0." + namespace + ".svc.cluster.local:6642",
@@ -127,6 +187,29 @@ var _ = Describe("OVNNorthd controller", func() {
 				corev1.ConditionTrue,
 			)
 		})
+
+		It("reports compatible versions when none are running", func() {
+			th.ExpectCondition(
+				ovnNorthdName,
+				ConditionGetterFunc(OVNNorthdConditionGetter),
+				ovnv1.VersionCompatibleCondition,
+				corev1.ConditionTrue,
+			)
+			Expect(GetOVNNorthd(ovnNorthdName).Status.Versions).To(BeEmpty())
+		})
+
+		It("does not report an active instance without running pods", func() {
+			th.ExpectCondition(
+				ovnNorthdName,
+				ConditionGetterFunc(OVNNorthdConditionGetter),
+				condition.ReadyCondition,
+				corev1.ConditionTrue,
+			)
+			ovnNorthd := GetOVNNorthd(ovnNorthdName)
+			Expect(ovnNorthd.Status.Conditions.Has(ovnv1.NorthdActiveCondition)).To(BeFalse())
+			Expect(ovnNorthd.Status.ActiveInstance).To(BeEmpty())
+			Expect(ovnNorthd.Status.StandbyInstances).To(BeEmpty())
+		})
 	})
 
 	When("OVNNorthd is created with nodeSelector", func() {
@@ -398,4 +481,396 @@ var _ = Describe("OVNNorthd controller", func() {
 			}, timeout, interval).Should(Succeed())
 		})
 	})
+
+	When("OVNNorthd is created with extraArgs", func() {
+		It("appends the options to the Deployment args", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.ExtraArgs = []string{"--ssl-protocols=TLSv1.2,TLSv1.3"}
+			ovnNorthdName := ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+
+			depl := th.GetDeployment(types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			})
+			Expect(depl.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--ssl-protocols=TLSv1.2,TLSv1.3"))
+		})
+
+		It("rejects options which are not allowed", func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.ExtraArgs = []string{"--ovnnb-db=tcp:127.0.0.1:6641"}
+			instance := &ovnv1.OVNNorthd{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovnnorthd-extraargs",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.extraArgs[0]"))
+		})
+	})
+
+	When("OVNNorthd is created with log modules", func() {
+		It("passes the log level to the pods through the runtime ConfigMap", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.LogModules = map[string]string{"jsonrpc": "dbg"}
+			ovnNorthdName := ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(types.NamespacedName{
+					Namespace: namespace,
+					Name:      ovnNorthdName.Name + "-runtime",
+				})
+				g.Expect(cm.Data).To(HaveKeyWithValue("log-level", "info"))
+				g.Expect(cm.Data).To(HaveKeyWithValue("log-modules", "jsonrpc:dbg"))
+			}, timeout, interval).Should(Succeed())
+
+			depl := th.GetDeployment(types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			})
+			var logLevel, logModules *corev1.EnvVar
+			for i, e := range depl.Spec.Template.Spec.Containers[0].Env {
+				switch e.Name {
+				case "OVN_LOG_LEVEL":
+					logLevel = &depl.Spec.Template.Spec.Containers[0].Env[i]
+				case "OVN_LOG_MODULES":
+					logModules = &depl.Spec.Template.Spec.Containers[0].Env[i]
+				}
+			}
+			Expect(logLevel).NotTo(BeNil())
+			Expect(logLevel.ValueFrom.ConfigMapKeyRef.Key).To(Equal("log-level"))
+			Expect(logModules).NotTo(BeNil())
+			Expect(logModules.ValueFrom.ConfigMapKeyRef.Key).To(Equal("log-modules"))
+		})
+
+		It("rejects unknown log modules", func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.LogModules = map[string]string{"Not A Module": "dbg"}
+			instance := &ovnv1.OVNNorthd{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovnnorthd-logmodules",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.logModules[Not A Module]"))
+		})
+	})
+
+	When("OVNNorthd is created with multiple replicas", func() {
+		It("creates a PodDisruptionBudget which can be overridden", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.Replicas = ptr.To[int32](3)
+			ovnNorthdName := ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+
+			pdbName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			}
+			Eventually(func(g Gomega) {
+				pdb := &policyv1.PodDisruptionBudget{}
+				g.Expect(k8sClient.Get(ctx, pdbName, pdb)).To(Succeed())
+				g.Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+				g.Expect(pdb.Spec.MinAvailable).To(BeNil())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				northd := ovn.GetOVNNorthd(ovnNorthdName)
+				northd.Spec.Override.PodDisruptionBudget = &ovnv1.PodDisruptionBudgetOverrideSpec{
+					MinAvailable: ptr.To(intstr.FromInt32(2)),
+				}
+				g.Expect(k8sClient.Update(ctx, northd)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				pdb := &policyv1.PodDisruptionBudget{}
+				g.Expect(k8sClient.Get(ctx, pdbName, pdb)).To(Succeed())
+				g.Expect(pdb.Spec.MinAvailable.IntValue()).To(Equal(2))
+				g.Expect(pdb.Spec.MaxUnavailable).To(BeNil())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNNorthd is created with scheduling settings", func() {
+		It("sets them in the Deployment", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.Tolerations = []corev1.Toleration{
+				{
+					Key:      "node-role.kubernetes.io/control-plane",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+			}
+			spec.PriorityClassName = "system-cluster-critical"
+			spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       corev1.LabelTopologyZone,
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+				},
+			}
+			ovnNorthdName := ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+
+			deplName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      "ovn-northd",
+			}
+			Eventually(func(g Gomega) {
+				podSpec := th.GetDeployment(deplName).Spec.Template.Spec
+				g.Expect(podSpec.Tolerations).To(Equal(spec.Tolerations))
+				g.Expect(podSpec.PriorityClassName).To(Equal("system-cluster-critical"))
+				// anti-affinity stays preferred by default
+				g.Expect(podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+				g.Expect(podSpec.TopologySpreadConstraints).To(HaveLen(1))
+				g.Expect(podSpec.TopologySpreadConstraints[0].LabelSelector.MatchLabels).To(
+					HaveKeyWithValue("service", "ovn-northd"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNNorthd is created with a Deployment override", func() {
+		It("applies the override on top of the generated Deployment", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.Override.Deployment = &runtime.RawExtension{
+				Raw: []byte(`{
+					"spec": {
+						"template": {
+							"spec": {
+								"containers": [{"name": "sidecar", "image": "sidecar:latest"}]
+							}
+						}
+					}
+				}`),
+			}
+			ovnNorthdName := ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+
+			Eventually(func(g Gomega) {
+				depl := th.GetDeployment(types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-northd",
+				})
+				containers := depl.Spec.Template.Spec.Containers
+				g.Expect(containers).To(HaveLen(2))
+				names := []string{containers[0].Name, containers[1].Name}
+				g.Expect(names).To(ContainElements("ovn-northd", "sidecar"))
+				envNames := []string{}
+				for _, c := range containers {
+					for _, e := range c.Env {
+						envNames = append(envNames, e.Name)
+					}
+				}
+				// the override is part of the input hash
+				g.Expect(envNames).To(ContainElement("CONFIG_HASH"))
+				g.Expect(envNames).NotTo(ContainElement("OverrideHash"))
+				g.Expect(ovn.GetOVNNorthd(ovnNorthdName).Status.Hash).To(HaveKey("input"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNNorthd is created with probe timings", func() {
+		It("uses the probe scripts with the configured timings", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.Probes = ovnv1.OVNNorthdProbesSpec{
+				PeriodSeconds:  10,
+				TimeoutSeconds: 15,
+			}
+			ovnNorthdName := ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(types.NamespacedName{
+					Namespace: namespace,
+					Name:      ovnNorthdName.Name + "-scripts",
+				})
+				g.Expect(cm.Data).To(HaveKey("ovn_northd_readiness.sh"))
+				g.Expect(cm.Data["ovn_northd_readiness.sh"]).To(ContainSubstring("-t /tmp/ovn-northd.ctl"))
+				g.Expect(cm.Data).To(HaveKey("ovn_northd_liveness.sh"))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				depl := th.GetDeployment(types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-northd",
+				})
+				container := depl.Spec.Template.Spec.Containers[0]
+				g.Expect(container.ReadinessProbe.Exec.Command).To(Equal(
+					[]string{"/usr/local/bin/container-scripts/ovn_northd_readiness.sh"}))
+				g.Expect(container.LivenessProbe.Exec.Command).To(Equal(
+					[]string{"/usr/local/bin/container-scripts/ovn_northd_liveness.sh"}))
+				g.Expect(container.ReadinessProbe.PeriodSeconds).To(Equal(int32(10)))
+				g.Expect(container.ReadinessProbe.TimeoutSeconds).To(Equal(int32(15)))
+				g.Expect(container.LivenessProbe.FailureThreshold).To(Equal(int32(3)))
+				g.Expect(container.Env).To(ContainElement(
+					corev1.EnvVar{Name: "OVN_NORTHD_APPCTL_TIMEOUT", Value: "14"}))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNNorthd is created with automatic thread tuning", func() {
+		It("derives the number of threads from the CPU limit within the bounds", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.NThreadsMode = ovnv1.NThreadsModeAuto
+			spec.NThreadsMax = ptr.To[int32](4)
+			spec.Resources = corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("6500m")},
+			}
+			ovnNorthdName := ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+
+			Eventually(func(g Gomega) {
+				depl := th.GetDeployment(types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-northd",
+				})
+				g.Expect(depl.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--n-threads=4"))
+				g.Expect(GetOVNNorthd(ovnNorthdName).Status.NThreads).To(Equal(int32(4)))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rejects a lower bound above the upper bound", func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.NThreadsMode = ovnv1.NThreadsModeAuto
+			spec.NThreadsMin = ptr.To[int32](8)
+			spec.NThreadsMax = ptr.To[int32](4)
+			instance := &ovnv1.OVNNorthd{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovnnorthd-threads",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must not be greater than nThreadsMax"))
+		})
+	})
+
+	When("OVNNorthd is created with the metrics sidecar", func() {
+		It("adds the sidecar sharing the run directory", func() {
+			dbs := CreateOVNDBClusters(namespace, map[string][]string{}, 1)
+			DeferCleanup(DeleteOVNDBClusters, dbs)
+			spec := GetDefaultOVNNorthdSpec()
+			spec.Metrics = ovnv1.OVNNorthdMetricsSpec{
+				Enabled:         true,
+				IntervalSeconds: 10,
+			}
+			ovnNorthdName := ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(types.NamespacedName{
+					Namespace: namespace,
+					Name:      ovnNorthdName.Name + "-scripts",
+				})
+				g.Expect(cm.Data).To(HaveKey("ovn_northd_metrics.sh"))
+				g.Expect(cm.Data["ovn_northd_metrics.sh"]).To(ContainSubstring("stopwatch/show"))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				depl := th.GetDeployment(types.NamespacedName{
+					Namespace: namespace,
+					Name:      "ovn-northd",
+				})
+				containers := depl.Spec.Template.Spec.Containers
+				g.Expect(containers).To(HaveLen(2))
+				g.Expect(containers[1].Name).To(Equal("ovn-northd-metrics"))
+				g.Expect(containers[1].Env).To(ContainElement(
+					corev1.EnvVar{Name: "OVN_NORTHD_METRICS_INTERVAL", Value: "10"}))
+				for _, container := range containers {
+					g.Expect(container.VolumeMounts).To(ContainElement(
+						corev1.VolumeMount{Name: "ovn-rundir", MountPath: "/tmp"}))
+				}
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("OVNNorthd is created with a sizing profile", func() {
+		It("expands the profile into the unset fields", func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.Profile = ovnv1.ProfileMedium
+			spec.Resources = corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+			}
+			ovnNorthdName := ovn.CreateOVNNorthd(namespace, spec)
+			DeferCleanup(ovn.DeleteOVNNorthd, ovnNorthdName)
+
+			northd := GetOVNNorthd(ovnNorthdName)
+			Expect(*northd.Spec.Replicas).To(Equal(int32(1)))
+			Expect(*northd.Spec.NThreads).To(Equal(int32(2)))
+			// explicit resources take precedence over the profile
+			Expect(northd.Spec.Resources.Requests).To(BeEmpty())
+			Expect(northd.Spec.Resources.Limits.Cpu().String()).To(Equal("3"))
+		})
+
+		It("rejects unknown profiles", func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.Profile = "huge"
+			instance := &ovnv1.OVNNorthd{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovnnorthd-profile",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			err := k8sClient.Create(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.profile"))
+		})
+	})
+
+	When("OVNNorthd is created with an invalid spec", func() {
+		It("rejects out of bounds threads and replicas", func() {
+			spec := GetDefaultOVNNorthdSpec()
+			spec.NThreads = ptr.To[int32](300)
+			spec.Replicas = ptr.To[int32](64)
+			errs := spec.OVNNorthdSpecCore.ValidateCreate(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(2))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.nThreads"))
+			Expect(errs.ToAggregate().Error()).To(ContainSubstring("spec.replicas"))
+
+			instance := &ovnv1.OVNNorthd{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ovnnorthd-bounds",
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+			Expect(k8sClient.Create(ctx, instance)).ToNot(Succeed())
+		})
+
+		It("warns when scaling down to 0 replicas", func() {
+			old := GetDefaultOVNNorthdSpec()
+			old.Replicas = ptr.To[int32](1)
+			spec := *old.DeepCopy()
+			spec.Replicas = ptr.To[int32](0)
+
+			warnings, err := (&ovnv1.OVNNorthd{Spec: spec}).ValidateUpdate(&ovnv1.OVNNorthd{Spec: old})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("spec.replicas")))
+		})
+	})
 })